@@ -9,6 +9,10 @@ var (
 	password = field.StringField("password", field.WithDescription("Application password used to connect to the Jenkins API"))
 	baseUrl  = field.StringField("base-url", field.WithDescription("Jenkins"), field.WithDefaultValue("http://localhost:8080"), field.WithRequired(true))
 	token    = field.StringField("token", field.WithDescription("HTTP access tokens in Jenkins"))
+
+	authorizationStrategy = field.StringField("authorization-strategy", field.WithDescription("Authorization strategy configured in Jenkins: role-strategy, matrix, project-matrix or folder-auth. Detected from Jenkins when not set"))
+	excludeSCMUsers       = field.BoolField("exclude-scm-users", field.WithDescription("Skip people only known as SCM commit authors, who have no Jenkins account"))
+	asynchPeople          = field.BoolField("asynch-people", field.WithDescription("List users through the asynchronous People API, which answers before walking the whole build history but whose pages may skip or repeat users"))
 )
 
 var relationships = []field.SchemaFieldRelationship{
@@ -16,4 +20,4 @@ var relationships = []field.SchemaFieldRelationship{
 	field.FieldsAtLeastOneUsed(token, password),
 }
 
-var configuration = field.NewConfiguration([]field.SchemaField{username, password, baseUrl, token}, relationships...)
+var configuration = field.NewConfiguration([]field.SchemaField{username, password, baseUrl, token, authorizationStrategy, excludeSCMUsers, asynchPeople}, relationships...)
@@ -47,7 +47,7 @@ func getConnector(ctx context.Context, v *viper.Viper) (types.ConnectorServer, e
 		jenkinsClient.WithUser(v.GetString("username")).WithPassword(v.GetString("password"))
 	}
 
-	cb, err := connector.New(ctx, v.GetString("base-url"), jenkinsClient)
+	cb, err := connector.New(ctx, v.GetString("base-url"), jenkinsClient, v.GetString("authorization-strategy"), v.GetBool("exclude-scm-users"), v.GetBool("asynch-people"))
 	if err != nil {
 		l.Error("error creating connector", zap.Error(err))
 		return nil, err
@@ -0,0 +1,184 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/conductorone/baton-sdk/pkg/uhttp"
+)
+
+// GET - http://{baseurl}/user/{userId}/api/json
+// POST - http://{baseurl}/securityRealm/createAccountByAdmin
+// POST - http://{baseurl}/user/{userId}/doDelete
+const (
+	getUser              = "user/%s/api/json"
+	createAccountByAdmin = "securityRealm/createAccountByAdmin"
+	deleteUser           = "user/%s/doDelete"
+)
+
+// accountsScript prints the users Jenkins holds a record for, sorted by ID, from start (inclusive) to end
+// (exclusive), in the same shape as the People API.
+const accountsScript = `
+import groovy.json.JsonOutput
+import hudson.model.User
+
+def users = User.getAll().sort { it.getId() }
+def page = users.subList(Math.min(%d, users.size()), Math.min(%d, users.size()))
+println(JsonOutput.toJson(page.collect { user ->
+  [user: [
+    id: user.getId(),
+    fullName: user.getFullName(),
+    description: user.getDescription(),
+    property: user.getAllProperties().collect { property ->
+      def name = property.getClass().getName()
+      name == 'hudson.tasks.Mailer$UserProperty' ? [_class: name, address: property.getAddress()] : [_class: name]
+    },
+  ]]
+}))
+`
+
+// lastChangesScript prints, by user ID, the time of the last build of a job including one of their commits, which
+// is the last change the People API reports.
+const lastChangesScript = `
+import groovy.json.JsonOutput
+import hudson.model.Job
+import jenkins.model.Jenkins
+import jenkins.scm.RunWithSCM
+
+def lastChanges = [:]
+Jenkins.get().allItems(Job).each { job ->
+  def build = job.getLastBuild()
+  if (build instanceof RunWithSCM) {
+    build.getChangeSets().each { changeSet ->
+      changeSet.each { entry ->
+        def id = entry.getAuthor().getId()
+        lastChanges[id] = Math.max(lastChanges.get(id, 0L), build.getTimeInMillis())
+      }
+    }
+  }
+}
+println(JsonOutput.toJson(lastChanges))
+`
+
+// formErrorPattern matches the validation errors Jenkins renders when it rejects a form.
+var formErrorPattern = regexp.MustCompile(`class="[^"]*error[^"]*"[^>]*>([^<]+)<`)
+
+// GetUser
+// Get a single user.
+func (d *JenkinsClient) GetUser(ctx context.Context, userId string) (*User, error) {
+	var userData User
+	req, endpointUrl, err := getRequest(ctx, d, d.baseUrl, fmt.Sprintf(getUser, url.PathEscape(userId)))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.httpClient.Do(req, uhttp.WithJSONResponse(&userData))
+	if err != nil {
+		return nil, getCustomError(err, resp, endpointUrl)
+	}
+
+	defer resp.Body.Close()
+
+	return &userData, nil
+}
+
+// GetAccounts
+// Get the users Jenkins holds a record for, sorted by ID, from start (inclusive) to end (exclusive), through the
+// script console, along with their last change. The last changes walk every job, so they are memoized rather than
+// read for every page.
+func (d *JenkinsClient) GetAccounts(ctx context.Context, start, end int) ([]Users, error) {
+	var users []Users
+	output, err := d.RunScript(ctx, fmt.Sprintf(accountsScript, start, end))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &users); err != nil {
+		return nil, fmt.Errorf("error reading users: %w: %s", err, output)
+	}
+
+	lastChanges, err := d.lastChanges.get(func() (map[string]int64, error) {
+		return d.getLastChanges(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range users {
+		users[i].LastChange = lastChanges[users[i].User.ID]
+	}
+
+	return users, nil
+}
+
+func (d *JenkinsClient) getLastChanges(ctx context.Context) (map[string]int64, error) {
+	var lastChanges map[string]int64
+	output, err := d.RunScript(ctx, lastChangesScript)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &lastChanges); err != nil {
+		return nil, fmt.Errorf("error reading last changes: %w: %s", err, output)
+	}
+
+	return lastChanges, nil
+}
+
+// CreateAccount
+// Create a user in the Jenkins own user database. Only available with the HudsonPrivateSecurityRealm.
+// Jenkins redirects to the user list on success and renders the sign-up form again with the validation
+// errors otherwise.
+func (d *JenkinsClient) CreateAccount(ctx context.Context, userName, password, fullName, email string) error {
+	form := url.Values{
+		"username":  {userName},
+		"password1": {password},
+		"password2": {password},
+		"fullname":  {fullName},
+		"email":     {email},
+	}
+	resp, endpointUrl, err := d.update(ctx, createAccountByAdmin, WithBody(form.Encode()))
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	if resp.Request == nil || !strings.HasSuffix(resp.Request.URL.Path, createAccountByAdmin) {
+		return nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	jenkinsErr := &JenkinsError{
+		ErrorMessage: fmt.Sprintf("Jenkins rejected the account %s", userName),
+		ErrorCode:    resp.StatusCode,
+		ErrorLink:    endpointUrl,
+	}
+	if match := formErrorPattern.FindSubmatch(body); match != nil {
+		jenkinsErr.ErrorMessage = fmt.Sprintf("%s: %s", jenkinsErr.ErrorMessage, strings.TrimSpace(html.UnescapeString(string(match[1]))))
+	}
+
+	return jenkinsErr
+}
+
+// DeleteUser
+// Delete a user. With the Jenkins own user database this removes the account, with other security realms only
+// the user record kept by Jenkins is removed.
+func (d *JenkinsClient) DeleteUser(ctx context.Context, userId string) error {
+	resp, _, err := d.update(ctx, fmt.Sprintf(deleteUser, url.PathEscape(userId)), WithBody(""))
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+	return nil
+}
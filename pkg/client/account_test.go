@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAccount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/securityRealm/createAccountByAdmin":
+			assert.Nil(t, r.ParseForm())
+			if r.PostForm.Get("username") == "taken" {
+				_, _ = w.Write([]byte(`<form><div class="error">User name is already taken</div></form>`))
+				return
+			}
+
+			assert.Equal(t, r.PostForm.Get("password1"), r.PostForm.Get("password2"))
+			http.Redirect(w, r, "/securityRealm/", http.StatusFound)
+		case "/securityRealm/":
+			_, _ = w.Write([]byte("<html></html>"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	cli, err := New(ctx, server.URL, NewClient().WithUser("admin").WithPassword("admin"))
+	assert.Nil(t, err)
+
+	err = cli.CreateAccount(ctx, "jane", "s3cret!pass", "Jane Doe", "jane@example.com")
+	assert.Nil(t, err)
+
+	err = cli.CreateAccount(ctx, "taken", "s3cret!pass", "Taken", "taken@example.com")
+	assert.ErrorContains(t, err, "User name is already taken")
+}
+
+func TestGetAccounts(t *testing.T) {
+	jobWalks := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/scriptText":
+			assert.Nil(t, r.ParseForm())
+			script := r.PostForm.Get("script")
+			switch {
+			case strings.Contains(script, "allItems(Job)"):
+				jobWalks++
+				_, _ = w.Write([]byte(`{"jane":1709251200000}` + "\n"))
+			case strings.Contains(script, "subList(Math.min(0, users.size()), Math.min(2, users.size()))"):
+				_, _ = w.Write([]byte(`[{"user":{"id":"jane","fullName":"Jane Doe"}},{"user":{"id":"john","fullName":"John Doe"}}]` + "\n"))
+			default:
+				_, _ = w.Write([]byte(`[{"user":{"id":"max","fullName":"Max Doe"}}]` + "\n"))
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	cli, err := New(ctx, server.URL, NewClient().WithUser("admin").WithPassword("admin"))
+	assert.Nil(t, err)
+
+	users, err := cli.GetAccounts(ctx, 0, 2)
+	assert.Nil(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, "jane", users[0].User.ID)
+	assert.Equal(t, int64(1709251200000), users[0].LastChange)
+	assert.Equal(t, int64(0), users[1].LastChange)
+
+	users, err = cli.GetAccounts(ctx, 2, 4)
+	assert.Nil(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, 1, jobWalks)
+}
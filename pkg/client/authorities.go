@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// authorityGroupsScript prints, as JSON, the groups the security realm granted to users when they last logged in.
+// Jenkins records these authorities for every user authenticating through LDAP, Active Directory and the like.
+const authorityGroupsScript = `
+import groovy.json.JsonOutput
+import hudson.model.User
+
+println(JsonOutput.toJson(User.getAll().collectMany { it.getAuthorities() }.unique().sort()))
+`
+
+// groupMembersScript prints, as JSON, the IDs of the users the security realm granted a group to.
+const groupMembersScript = `
+import groovy.json.JsonOutput
+import hudson.model.User
+
+def group = %s
+println(JsonOutput.toJson(User.getAll().findAll { it.getAuthorities().contains(group) }.collect { it.getId() }.sort()))
+`
+
+// GetAuthorityGroups
+// Get the groups the security realm granted to users when they last logged in. They are memoized until the client
+// changes the configuration or they expire.
+func (d *JenkinsClient) GetAuthorityGroups(ctx context.Context) ([]Group, error) {
+	return d.authorityGroups.get(func() ([]Group, error) {
+		return d.getAuthorityGroups(ctx)
+	})
+}
+
+func (d *JenkinsClient) getAuthorityGroups(ctx context.Context) ([]Group, error) {
+	var groupIDs []string
+	output, err := d.RunScript(ctx, authorityGroupsScript)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &groupIDs); err != nil {
+		return nil, fmt.Errorf("error reading groups: %w: %s", err, output)
+	}
+
+	return removeDuplicates(groupIDs), nil
+}
+
+// GetGroupMembers
+// Get the IDs of the users the security realm granted a group to when they last logged in.
+func (d *JenkinsClient) GetGroupMembers(ctx context.Context, group string) ([]string, error) {
+	var userIDs []string
+	output, err := d.RunScript(ctx, fmt.Sprintf(groupMembersScript, groovyString(group)))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &userIDs); err != nil {
+		return nil, fmt.Errorf("error reading the members of %s: %w: %s", group, err, output)
+	}
+
+	return userIDs, nil
+}
@@ -7,16 +7,27 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/cookiejar"
 	"net/url"
+	"sort"
+	"strings"
+	"sync"
 
 	"github.com/conductorone/baton-sdk/pkg/uhttp"
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
 )
 
 type JenkinsClient struct {
-	auth       *auth
-	httpClient *uhttp.BaseHttpClient
-	baseUrl    string
+	auth            *auth
+	httpClient      *uhttp.BaseHttpClient
+	baseUrl         string
+	crumbMu         sync.Mutex
+	crumb           *CrumbAPIData
+	roleDefinitions memo[[]RolesAPIData]
+	authorityGroups memo[[]Group]
+	lastChanges     memo[map[string]int64]
+	globalMatrix    memo[*MatrixAPIData]
 }
 
 type JenkinsError struct {
@@ -33,31 +44,51 @@ func (b *JenkinsError) Error() string {
 	return b.ErrorMessage
 }
 
-// GET - http://{baseurl}/computer/api/json?pretty&tree=computer[displayName,description,idle,manualLaunchAllowed,assignedLabels[name]]
-// GET - http://{baseurl}/api/json?pretty&tree=jobs[name,url,color,buildable]
+// GET - http://{baseurl}/computer/api/json?pretty&tree=computer[name,displayName,description,idle,manualLaunchAllowed,assignedLabels[name]]
+// GET - http://{baseurl}/{job/folder/}api/json?pretty&tree=jobs[name,fullName,url,color,buildable,jobs[name]]
 // GET - http://{baseurl}/api/json?pretty&tree=views[name,url]
-// GET - http://{baseurl}/asynchPeople/api/json?pretty&depth=3
-// GET - http://{baseurl}/role-strategy/strategy/getAllRoles?type=globalRoles
-// GET - http://{baseurl}/role-strategy/strategy/getAllRoles?type=projectRoles
-// GET - http://{baseurl}/role-strategy/strategy/getAllRoles?type=slaveRoles
+// GET - http://{baseurl}/people/api/json?pretty&tree=users[lastChange,user[id,fullName,description,absoluteUrl,property[address]]]{start,end}
+// GET - http://{baseurl}/asynchPeople/api/json?pretty&tree=users[lastChange,user[id,fullName,description,absoluteUrl,property[address]]]{start,end}
+// GET - http://{baseurl}/role-strategy/strategy/getAllRoles?type={globalRoles|projectRoles|slaveRoles}
+// GET - http://{baseurl}/role-strategy/strategy/getRole?type={globalRoles|projectRoles|slaveRoles}&roleName={roleName}
 // POST - http://{baseurl}/role-strategy/strategy/assignUserRole
 // POST - http://{baseurl}/role-strategy/strategy/assignGroupRole
 // POST - http://{baseurl}/role-strategy/strategy/unassignUserRole
 // POST - http://{baseurl}/role-strategy/strategy/unassignGroupRole
+// POST - http://{baseurl}/role-strategy/strategy/unassignRole
+// POST - http://{baseurl}/role-strategy/strategy/addRole
+// POST - http://{baseurl}/role-strategy/strategy/removeRoles
+// GET - http://{baseurl}/whoAmI/api/json
+// GET - http://{baseurl}/pluginManager/api/json?tree=plugins[shortName,version,active,enabled]
 const (
-	allNodes          = "computer/api/json?pretty&tree=computer[displayName,description,idle,manualLaunchAllowed,assignedLabels[name]]"
-	allJobs           = "api/json?pretty&tree=jobs[name,url,color,buildable]"
+	allNodes          = "computer/api/json?pretty&tree=computer[name,displayName,description,idle,manualLaunchAllowed,assignedLabels[name]]"
+	allJobs           = "%sapi/json?pretty&tree=jobs[name,fullName,url,color,buildable,jobs[name]]"
 	allViews          = "api/json?pretty&tree=views[name,url]"
-	allUsers          = "asynchPeople/api/json?pretty&depth=3"
-	allGlobalRoles    = "role-strategy/strategy/getAllRoles?type=globalRoles"
-	allProjectRoles   = "role-strategy/strategy/getAllRoles?type=projectRoles"
-	allSlaveRoles     = "role-strategy/strategy/getAllRoles?type=slaveRoles"
+	allUsers          = "people/api/json?pretty&tree=users[lastChange,user[id,fullName,description,absoluteUrl,property[address]]]{%d,%d}"
+	allAsynchUsers    = "asynchPeople/api/json?pretty&tree=users[lastChange,user[id,fullName,description,absoluteUrl,property[address]]]{%d,%d}"
+	allRoles          = "role-strategy/strategy/getAllRoles?type=%s"
+	getRole           = "role-strategy/strategy/getRole?type=%s&roleName=%s"
 	assignUserRole    = "role-strategy/strategy/assignUserRole"
 	assignGroupRole   = "role-strategy/strategy/assignGroupRole"
 	unassignUserRole  = "role-strategy/strategy/unassignUserRole"
 	unassignGroupRole = "role-strategy/strategy/unassignGroupRole"
+	unassignRole      = "role-strategy/strategy/unassignRole"
+	addRole           = "role-strategy/strategy/addRole"
+	removeRoles       = "role-strategy/strategy/removeRoles"
+	whoAmI            = "whoAmI/api/json"
+	allPlugins        = "pluginManager/api/json?tree=plugins[shortName,version,active,enabled]"
 )
 
+// Role types exposed by the Role Strategy plugin.
+const (
+	GlobalRoles  = "globalRoles"
+	ProjectRoles = "projectRoles"
+	SlaveRoles   = "slaveRoles"
+)
+
+// RoleTypes lists every role type in the order they are synced.
+var RoleTypes = []string{GlobalRoles, ProjectRoles, SlaveRoles}
+
 type auth struct {
 	user, password string
 	bearerToken    string
@@ -168,6 +199,12 @@ func New(ctx context.Context, baseUrl string, jenkinsClient *JenkinsClient) (*Je
 		return nil, err
 	}
 
+	// CSRF crumbs are only valid for the session they were issued to.
+	httpClient.Jar, err = cookiejar.New(nil)
+	if err != nil {
+		return nil, err
+	}
+
 	cli := uhttp.NewBaseHttpClient(httpClient)
 	if !isValidUrl(baseUrl) {
 		return nil, fmt.Errorf("the url : %s is not valid", baseUrl)
@@ -241,25 +278,102 @@ func WithBody(body string) uhttp.RequestOption {
 	}
 }
 
-func getPostRequest(ctx context.Context, cli *JenkinsClient, baseUrl, apiUrl, body string) (*http.Request, string, error) {
-	endpointUrl := fmt.Sprintf("%s/%s", baseUrl, apiUrl)
+func WithXMLBody(body string) uhttp.RequestOption {
+	return func() (io.ReadWriter, map[string]string, error) {
+		var buffer bytes.Buffer
+		_, err := buffer.WriteString(body)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return &buffer, map[string]string{
+			"Content-Type": "application/xml",
+		}, nil
+	}
+}
+
+// post sends a POST request carrying the CSRF crumb of the session. When Jenkins rejects the crumb, it may have
+// expired with the session, so a new one is fetched and the request is sent once more.
+func (d *JenkinsClient) post(ctx context.Context, apiUrl string, body uhttp.RequestOption) (*http.Response, string, error) {
+	endpointUrl := fmt.Sprintf("%s/%s", d.baseUrl, apiUrl)
 	uri, err := url.Parse(endpointUrl)
 	if err != nil {
 		return nil, "", err
 	}
 
-	req, err := cli.httpClient.NewRequest(ctx,
-		http.MethodPost,
-		uri,
-		uhttp.WithAcceptXMLHeader(),
-		WithAuthorization(cli.getUser(), cli.getPWD(), cli.getToken()),
-		WithBody(body),
-	)
+	for attempt := 0; ; attempt++ {
+		crumb, err := d.getCrumb(ctx, attempt > 0)
+		if err != nil {
+			return nil, endpointUrl, err
+		}
+
+		req, err := d.httpClient.NewRequest(ctx,
+			http.MethodPost,
+			uri,
+			uhttp.WithAcceptXMLHeader(),
+			WithAuthorization(d.getUser(), d.getPWD(), d.getToken()),
+			WithCrumb(crumb),
+			body,
+		)
+		if err != nil {
+			return nil, endpointUrl, err
+		}
+
+		resp, err := d.httpClient.Do(req)
+		if err == nil {
+			return resp, endpointUrl, nil
+		}
+
+		if resp == nil {
+			return nil, endpointUrl, err
+		}
+
+		if attempt == 0 && isCrumbRejection(resp) {
+			resp.Body.Close()
+			continue
+		}
+
+		return resp, endpointUrl, getCustomError(err, resp, endpointUrl)
+	}
+}
+
+// isCrumbRejection reports whether Jenkins rejected a request because of a missing or expired CSRF crumb, rather
+// than for lack of permission. The response body is left readable.
+func isCrumbRejection(resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden {
+		return false
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
 	if err != nil {
-		return nil, "", err
+		return false
 	}
 
-	return req, endpointUrl, nil
+	return strings.Contains(string(body), "No valid crumb")
+}
+
+// update sends a POST request changing the Jenkins configuration. Successful requests clear the response cache,
+// so that the changes they make are visible to later reads.
+func (d *JenkinsClient) update(ctx context.Context, apiUrl string, body uhttp.RequestOption) (*http.Response, string, error) {
+	resp, endpointUrl, err := d.post(ctx, apiUrl, body)
+	if err == nil {
+		d.clearCaches(ctx)
+	}
+
+	return resp, endpointUrl, err
+}
+
+// clearCaches drops the cached responses and the memoized values after a change.
+func (d *JenkinsClient) clearCaches(ctx context.Context) {
+	d.roleDefinitions.reset()
+	d.authorityGroups.reset()
+	d.lastChanges.reset()
+	d.globalMatrix.reset()
+	if err := uhttp.ClearCaches(ctx); err != nil {
+		ctxzap.Extract(ctx).Warn("jenkins-connector: unable to clear the response cache", zap.Error(err))
+	}
 }
 
 func getCustomError(err error, resp *http.Response, endpointUrl string) *JenkinsError {
@@ -305,11 +419,28 @@ func (d *JenkinsClient) SetClient(httpClient *uhttp.BaseHttpClient) {
 	d.httpClient = httpClient
 }
 
+// JobPath returns the URL path of an item from its full name, e.g. job/folder/job/pipeline/.
+func JobPath(fullName string) string {
+	var path strings.Builder
+	for _, name := range strings.Split(fullName, "/") {
+		if name == "" {
+			continue
+		}
+
+		path.WriteString("job/")
+		path.WriteString(url.PathEscape(name))
+		path.WriteString("/")
+	}
+
+	return path.String()
+}
+
 // GetJobs
-// Get all jobs.
-func (d *JenkinsClient) GetJobs(ctx context.Context) ([]Job, error) {
+// Get the items of a folder, or the top-level items when folder is empty.
+// Folders, organization folders and multibranch projects are returned with a non-nil Jobs list.
+func (d *JenkinsClient) GetJobs(ctx context.Context, folder string) ([]Job, error) {
 	var jobData JobsAPIData
-	req, endpointUrl, err := getRequest(ctx, d, d.baseUrl, allJobs)
+	req, endpointUrl, err := getRequest(ctx, d, d.baseUrl, fmt.Sprintf(allJobs, JobPath(folder)))
 	if err != nil {
 		return nil, err
 	}
@@ -344,10 +475,23 @@ func (d *JenkinsClient) GetViews(ctx context.Context) ([]View, error) {
 }
 
 // GetUsers
-// Get all users.
-func (d *JenkinsClient) GetUsers(ctx context.Context) ([]Users, error) {
+// Get the people known to Jenkins from start (inclusive) to end (exclusive), accounts and SCM commit authors alike.
+// The list is computed before answering, so that pages neither skip nor repeat people.
+func (d *JenkinsClient) GetUsers(ctx context.Context, start, end int) ([]Users, error) {
+	return d.getPeople(ctx, fmt.Sprintf(allUsers, start, end))
+}
+
+// GetAsynchUsers
+// Get the people known to Jenkins from start (inclusive) to end (exclusive) through the asynchronous People API.
+// It answers with the people found so far while it walks the build history, unsorted, so pages may skip or
+// repeat people.
+func (d *JenkinsClient) GetAsynchUsers(ctx context.Context, start, end int) ([]Users, error) {
+	return d.getPeople(ctx, fmt.Sprintf(allAsynchUsers, start, end))
+}
+
+func (d *JenkinsClient) getPeople(ctx context.Context, apiUrl string) ([]Users, error) {
 	var userData UsersAPIData
-	req, endpointUrl, err := getRequest(ctx, d, d.baseUrl, allUsers)
+	req, endpointUrl, err := getRequest(ctx, d, d.baseUrl, apiUrl)
 	if err != nil {
 		return nil, err
 	}
@@ -362,16 +506,55 @@ func (d *JenkinsClient) GetUsers(ctx context.Context) ([]Users, error) {
 	return userData.Users, nil
 }
 
+// WhoAmI
+// Get the identity Jenkins associates with the configured credentials.
+func (d *JenkinsClient) WhoAmI(ctx context.Context) (*WhoAmIAPIData, error) {
+	var whoAmIData WhoAmIAPIData
+	req, endpointUrl, err := getRequest(ctx, d, d.baseUrl, whoAmI)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.httpClient.Do(req, uhttp.WithJSONResponse(&whoAmIData))
+	if err != nil {
+		return nil, getCustomError(err, resp, endpointUrl)
+	}
+
+	defer resp.Body.Close()
+	whoAmIData.Version = resp.Header.Get("X-Jenkins")
+
+	return &whoAmIData, nil
+}
+
+// GetPlugins
+// Get all installed plugins. Requires the Overall/Administer permission.
+func (d *JenkinsClient) GetPlugins(ctx context.Context) ([]Plugin, error) {
+	var pluginData PluginsAPIData
+	req, endpointUrl, err := getRequest(ctx, d, d.baseUrl, allPlugins)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.httpClient.Do(req, uhttp.WithJSONResponse(&pluginData))
+	if err != nil {
+		return nil, getCustomError(err, resp, endpointUrl)
+	}
+
+	defer resp.Body.Close()
+
+	return pluginData.Plugins, nil
+}
+
 // GetRoles
-// Get all roles.
-func (d *JenkinsClient) GetRoles(ctx context.Context, apiUrl string) ([]RolesAPIData, error) {
+// Get all roles of the given type (globalRoles, projectRoles or slaveRoles).
+func (d *JenkinsClient) GetRoles(ctx context.Context, roleType string) ([]RolesAPIData, error) {
 	var (
 		rolesAPIData []RolesAPIData
 		roleData     map[string]any
 		roleDetail   []any
 		ok           bool
 	)
-	req, endpointUrl, err := getRequest(ctx, d, d.baseUrl, apiUrl)
+	req, endpointUrl, err := getRequest(ctx, d, d.baseUrl, fmt.Sprintf(allRoles, roleType))
 	if err != nil {
 		return nil, err
 	}
@@ -397,6 +580,7 @@ func (d *JenkinsClient) GetRoles(ctx context.Context, apiUrl string) ([]RolesAPI
 		}
 		rolesAPIData = append(rolesAPIData, RolesAPIData{
 			RoleName:   roleName,
+			RoleType:   roleType,
 			RoleDetail: roles,
 		})
 	}
@@ -408,25 +592,96 @@ func (d *JenkinsClient) GetRoles(ctx context.Context, apiUrl string) ([]RolesAPI
 // Get all roles.
 func (d *JenkinsClient) GetAllRoles(ctx context.Context) ([]RolesAPIData, error) {
 	var allRoles []RolesAPIData
-	roles, err := d.GetRoles(ctx, allGlobalRoles)
+	for _, roleType := range RoleTypes {
+		roles, err := d.GetRoles(ctx, roleType)
+		if err != nil {
+			return nil, err
+		}
+
+		allRoles = append(allRoles, roles...)
+	}
+
+	return allRoles, nil
+}
+
+// GetRole
+// Get a single role together with its permission set and, for project and agent roles, its pattern.
+// https://javadoc.jenkins.io/plugin/role-strategy/com/michelin/cio/hudson/plugins/rolestrategy/RoleBasedAuthorizationStrategy.html#doGetRole(java.lang.String,java.lang.String)
+func (d *JenkinsClient) GetRole(ctx context.Context, roleType, roleName string) (*RolesAPIData, error) {
+	var roleData RoleAPIData
+	req, endpointUrl, err := getRequest(ctx, d, d.baseUrl, fmt.Sprintf(getRole, roleType, url.QueryEscape(roleName)))
 	if err != nil {
 		return nil, err
 	}
 
-	allRoles = append(allRoles, roles...)
-	roles, err = d.GetRoles(ctx, allProjectRoles)
+	resp, err := d.httpClient.Do(req, uhttp.WithJSONResponse(&roleData))
 	if err != nil {
-		return nil, err
+		return nil, getCustomError(err, resp, endpointUrl)
+	}
+
+	defer resp.Body.Close()
+	var permissions []string
+	for permissionId, granted := range roleData.PermissionIds {
+		if granted {
+			permissions = append(permissions, permissionId)
+		}
+	}
+
+	sort.Strings(permissions)
+	var roles []Role
+	for _, sid := range roleData.Sids {
+		switch item := sid.(type) {
+		case map[string]any:
+			roles = append(roles, Role{
+				Sid:  fmt.Sprint(item["sid"]),
+				Type: fmt.Sprint(item["type"]),
+			})
+		case string:
+			// Older plugin versions only report the sid, without telling users and groups apart.
+			roles = append(roles, Role{
+				Sid:  item,
+				Type: "EITHER",
+			})
+		}
 	}
 
-	allRoles = append(allRoles, roles...)
-	roles, err = d.GetRoles(ctx, allSlaveRoles)
+	return &RolesAPIData{
+		RoleName:    roleName,
+		RoleType:    roleType,
+		RoleDetail:  roles,
+		Permissions: permissions,
+		Pattern:     roleData.Pattern,
+	}, nil
+}
+
+// GetAllRoleDefinitions
+// Get all roles including their permission sets and patterns. Reading them takes one request per role, so they are
+// memoized until the client changes the configuration or they expire.
+func (d *JenkinsClient) GetAllRoleDefinitions(ctx context.Context) ([]RolesAPIData, error) {
+	return d.roleDefinitions.get(func() ([]RolesAPIData, error) {
+		return d.getAllRoleDefinitions(ctx)
+	})
+}
+
+func (d *JenkinsClient) getAllRoleDefinitions(ctx context.Context) ([]RolesAPIData, error) {
+	var definitions []RolesAPIData
+	roles, err := d.GetAllRoles(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	allRoles = append(allRoles, roles...)
-	return allRoles, nil
+	for _, role := range roles {
+		definition, err := d.GetRole(ctx, role.RoleType, role.RoleName)
+		if err != nil {
+			return nil, err
+		}
+
+		// getAllRoles always reports typed sids, prefer them over the ones returned by getRole.
+		definition.RoleDetail = role.RoleDetail
+		definitions = append(definitions, *definition)
+	}
+
+	return definitions, nil
 }
 
 // GetGroups
@@ -475,38 +730,26 @@ func removeDuplicates(groupIDs []string) []Group {
 
 // AssignUserRole
 // Assign User Role.
-func (d *JenkinsClient) AssignUserRole(ctx context.Context, roleName, userName string) (int, error) {
-	var body = fmt.Sprintf("type=globalRoles&roleName=%s&user=%s", roleName, userName)
-	req, endpointUrl, err := getPostRequest(ctx, d, d.baseUrl, assignUserRole, body)
-	if err != nil {
-		return http.StatusBadRequest, err
-	}
-
-	resp, err := d.httpClient.Do(req)
-	if err != nil && resp.StatusCode != http.StatusOK {
-		return http.StatusBadRequest, getCustomError(err, resp, endpointUrl)
+func (d *JenkinsClient) AssignUserRole(ctx context.Context, roleType, roleName, userName string) (int, error) {
+	form := url.Values{
+		"type":     {roleType},
+		"roleName": {roleName},
+		"user":     {userName},
 	}
 
-	defer resp.Body.Close()
-	return resp.StatusCode, nil
+	return d.postForm(ctx, assignUserRole, form)
 }
 
 // AssignGroupRole
 // Assign Group Role.
-func (d *JenkinsClient) AssignGroupRole(ctx context.Context, roleName, groupName string) (int, error) {
-	var body = fmt.Sprintf("type=globalRoles&roleName=%s&group=%s", roleName, groupName)
-	req, endpointUrl, err := getPostRequest(ctx, d, d.baseUrl, assignGroupRole, body)
-	if err != nil {
-		return http.StatusBadRequest, err
-	}
-
-	resp, err := d.httpClient.Do(req)
-	if err != nil && resp.StatusCode != http.StatusOK {
-		return http.StatusBadRequest, getCustomError(err, resp, endpointUrl)
+func (d *JenkinsClient) AssignGroupRole(ctx context.Context, roleType, roleName, groupName string) (int, error) {
+	form := url.Values{
+		"type":     {roleType},
+		"roleName": {roleName},
+		"group":    {groupName},
 	}
 
-	defer resp.Body.Close()
-	return resp.StatusCode, nil
+	return d.postForm(ctx, assignGroupRole, form)
 }
 
 // UnassignUserRole
@@ -514,20 +757,14 @@ func (d *JenkinsClient) AssignGroupRole(ctx context.Context, roleName, groupName
 //	Unassign User roles.
 //
 // https://javadoc.jenkins.io/plugin/role-strategy/com/michelin/cio/hudson/plugins/rolestrategy/RoleBasedAuthorizationStrategy.html#doGetRole(java.lang.String,java.lang.String)
-func (d *JenkinsClient) UnassignUserRole(ctx context.Context, roleName, userName string) (int, error) {
-	var body = fmt.Sprintf("type=globalRoles&roleName=%s&user=%s", roleName, userName)
-	req, endpointUrl, err := getPostRequest(ctx, d, d.baseUrl, unassignUserRole, body)
-	if err != nil {
-		return http.StatusBadRequest, err
-	}
-
-	resp, err := d.httpClient.Do(req)
-	if err != nil && resp.StatusCode != http.StatusOK {
-		return http.StatusBadRequest, getCustomError(err, resp, endpointUrl)
+func (d *JenkinsClient) UnassignUserRole(ctx context.Context, roleType, roleName, userName string) (int, error) {
+	form := url.Values{
+		"type":     {roleType},
+		"roleName": {roleName},
+		"user":     {userName},
 	}
 
-	defer resp.Body.Close()
-	return resp.StatusCode, nil
+	return d.postForm(ctx, unassignUserRole, form)
 }
 
 // UnassignGroupRole
@@ -535,18 +772,76 @@ func (d *JenkinsClient) UnassignUserRole(ctx context.Context, roleName, userName
 //	Unassign Group roles.
 //
 // https://javadoc.jenkins.io/plugin/role-strategy/com/michelin/cio/hudson/plugins/rolestrategy/RoleBasedAuthorizationStrategy.html#doGetRole(java.lang.String,java.lang.String)
-func (d *JenkinsClient) UnassignGroupRole(ctx context.Context, roleName, groupName string) (int, error) {
-	var body = fmt.Sprintf("type=globalRoles&roleName=%s&group=%s", roleName, groupName)
-	req, endpointUrl, err := getPostRequest(ctx, d, d.baseUrl, unassignGroupRole, body)
-	if err != nil {
-		return http.StatusBadRequest, err
+func (d *JenkinsClient) UnassignGroupRole(ctx context.Context, roleType, roleName, groupName string) (int, error) {
+	form := url.Values{
+		"type":     {roleType},
+		"roleName": {roleName},
+		"group":    {groupName},
+	}
+
+	return d.postForm(ctx, unassignGroupRole, form)
+}
+
+// UnassignRole
+// Unassign a role from an ambiguous sid, which does not say whether it refers to a user or a group.
+// https://javadoc.jenkins.io/plugin/role-strategy/com/michelin/cio/hudson/plugins/rolestrategy/RoleBasedAuthorizationStrategy.html#doUnassignRole(java.lang.String,java.lang.String,java.lang.String)
+func (d *JenkinsClient) UnassignRole(ctx context.Context, roleType, roleName, sid string) (int, error) {
+	form := url.Values{
+		"type":     {roleType},
+		"roleName": {roleName},
+		"sid":      {sid},
 	}
 
-	resp, err := d.httpClient.Do(req)
-	if err != nil && resp.StatusCode != http.StatusOK {
-		return http.StatusBadRequest, getCustomError(err, resp, endpointUrl)
+	return d.postForm(ctx, unassignRole, form)
+}
+
+// postForm sends a form encoded POST request changing the Jenkins configuration and returns the response status code.
+func (d *JenkinsClient) postForm(ctx context.Context, apiUrl string, form url.Values) (int, error) {
+	resp, _, err := d.update(ctx, apiUrl, WithBody(form.Encode()))
+	if err != nil {
+		if resp == nil {
+			return http.StatusBadRequest, err
+		}
+
+		return resp.StatusCode, err
 	}
 
 	defer resp.Body.Close()
 	return resp.StatusCode, nil
 }
+
+// AddRole
+// Create a role with the given permission IDs. The pattern is only used by project and agent roles.
+// https://javadoc.jenkins.io/plugin/role-strategy/com/michelin/cio/hudson/plugins/rolestrategy/RoleBasedAuthorizationStrategy.html#doAddRole(java.lang.String,java.lang.String,java.lang.String,java.lang.String,java.lang.String,java.lang.String)
+func (d *JenkinsClient) AddRole(ctx context.Context, roleType, roleName string, permissionIds []string, pattern string) (int, error) {
+	form := url.Values{
+		"type":          {roleType},
+		"roleName":      {roleName},
+		"permissionIds": {strings.Join(permissionIds, ",")},
+		"overwrite":     {"false"},
+	}
+	if pattern != "" {
+		form.Set("pattern", pattern)
+	}
+
+	return d.postForm(ctx, addRole, form)
+}
+
+// RemoveRoles
+// Remove the given roles of a role type. The plugin splits the role names on commas, so roles whose name contains
+// a comma cannot be removed through it.
+// https://javadoc.jenkins.io/plugin/role-strategy/com/michelin/cio/hudson/plugins/rolestrategy/RoleBasedAuthorizationStrategy.html#doRemoveRoles(java.lang.String,java.lang.String)
+func (d *JenkinsClient) RemoveRoles(ctx context.Context, roleType string, roleNames ...string) (int, error) {
+	for _, roleName := range roleNames {
+		if strings.Contains(roleName, ",") {
+			return http.StatusBadRequest, fmt.Errorf("role %s cannot be removed, its name contains a comma", roleName)
+		}
+	}
+
+	form := url.Values{
+		"type":      {roleType},
+		"roleNames": {strings.Join(roleNames, ",")},
+	}
+
+	return d.postForm(ctx, removeRoles, form)
+}
@@ -0,0 +1,111 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobPath(t *testing.T) {
+	assert.Equal(t, "", JobPath(""))
+	assert.Equal(t, "job/pipeline/", JobPath("pipeline"))
+	assert.Equal(t, "job/team%20a/job/service/job/main/", JobPath("team a/service/main"))
+}
+
+const jobConfigForTesting = `<?xml version='1.1' encoding='UTF-8'?>
+<flow-definition plugin="workflow-job@1400.v7fd111b_ec82f">
+  <description>deploys &amp; releases</description>
+  <properties>
+    <hudson.security.AuthorizationMatrixProperty>
+      <inheritanceStrategy class="org.jenkinsci.plugins.matrixauth.inheritance.NonInheritingStrategy"/>
+      <permission>USER:hudson.model.Item.Build:alice</permission>
+      <permission>hudson.model.Item.Read:ops</permission>
+    </hudson.security.AuthorizationMatrixProperty>
+  </properties>
+</flow-definition>`
+
+func TestParseItemMatrix(t *testing.T) {
+	matrix, err := ParseItemMatrix(jobConfigForTesting)
+	assert.Nil(t, err)
+	assert.Equal(t, NonInheritingStrategy, matrix.InheritanceStrategy)
+	assert.Equal(t, []MatrixEntry{
+		{Permission: "hudson.model.Item.Build", Sid: "alice", Type: SidTypeUser},
+		{Permission: "hudson.model.Item.Read", Sid: "ops", Type: SidTypeEither},
+	}, matrix.Entries)
+
+	matrix, err = ParseItemMatrix(`<?xml version='1.1' encoding='UTF-8'?><project><properties/></project>`)
+	assert.Nil(t, err)
+	assert.Nil(t, matrix)
+}
+
+func TestItemMatrixEntries(t *testing.T) {
+	entry := MatrixEntry{Permission: "hudson.model.Item.Configure", Sid: "dev & ops", Type: SidTypeGroup}
+	config, err := addItemMatrixEntry(jobConfigForTesting, false, entry)
+	assert.Nil(t, err)
+	matrix, err := ParseItemMatrix(config)
+	assert.Nil(t, err)
+	assert.Contains(t, matrix.Entries, entry)
+
+	config, removed, err := removeItemMatrixEntry(config, entry)
+	assert.Nil(t, err)
+	assert.True(t, removed)
+	assert.Equal(t, jobConfigForTesting, config)
+
+	config, removed, err = removeItemMatrixEntry(config, MatrixEntry{Permission: "hudson.model.Item.Read", Sid: "ops", Type: SidTypeGroup})
+	assert.Nil(t, err)
+	assert.True(t, removed)
+	matrix, err = ParseItemMatrix(config)
+	assert.Nil(t, err)
+	assert.Len(t, matrix.Entries, 1)
+
+	config, err = addItemMatrixEntry(`<?xml version='1.1' encoding='UTF-8'?><com.cloudbees.hudson.plugins.folder.Folder><properties/></com.cloudbees.hudson.plugins.folder.Folder>`, true, entry)
+	assert.Nil(t, err)
+	matrix, err = ParseItemMatrix(config)
+	assert.Nil(t, err)
+	assert.Equal(t, InheritParentStrategy, matrix.InheritanceStrategy)
+	assert.Equal(t, []MatrixEntry{entry}, matrix.Entries)
+	assert.Contains(t, config, folderMatrixProperty)
+}
+
+func TestUserEmail(t *testing.T) {
+	var users UsersAPIData
+	err := json.Unmarshal([]byte(`{"users":[{"lastChange":1709251200000,"user":{"id":"jane","fullName":"Jane Doe",
+		"description":null,"property":[{"_class":"jenkins.security.ApiTokenProperty"},
+		{"_class":"hudson.tasks.Mailer$UserProperty","address":"jane@example.com"}]}}]}`), &users)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1709251200000), users.Users[0].LastChange)
+	assert.Equal(t, "jane@example.com", users.Users[0].User.Email())
+	assert.Equal(t, "", User{ID: "bob"}.Email())
+	assert.False(t, users.Users[0].User.HasAccount())
+	assert.True(t, User{Property: []UserProperty{{Class: "hudson.security.HudsonPrivateSecurityRealm$Details"}}}.HasAccount())
+}
+
+func TestRemoveRolesRejectsCommas(t *testing.T) {
+	var removed []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/role-strategy/strategy/removeRoles" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		assert.Nil(t, r.ParseForm())
+		removed = append(removed, r.PostForm.Get("roleNames"))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	cli, err := New(ctx, server.URL, NewClient().WithUser("admin").WithPassword("admin"))
+	assert.Nil(t, err)
+
+	_, err = cli.RemoveRoles(ctx, GlobalRoles, "dev,ops")
+	assert.NotNil(t, err)
+	assert.Empty(t, removed)
+
+	_, err = cli.RemoveRoles(ctx, GlobalRoles, "dev", "ops")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"dev,ops"}, removed)
+}
@@ -0,0 +1,99 @@
+package client
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"slices"
+	"strings"
+)
+
+// xmlElement is an element of a config.xml document together with its byte offsets, so that the document
+// can be edited without re-serializing the parts the connector does not understand.
+type xmlElement struct {
+	path       []string
+	attrs      []xml.Attr
+	text       string
+	start      int64
+	innerStart int64
+	innerEnd   int64
+	end        int64
+}
+
+// selfClosing reports whether the element was written as <name/>.
+func (e *xmlElement) selfClosing() bool {
+	return e.innerStart == e.end
+}
+
+// contains reports whether other is nested inside the element.
+func (e *xmlElement) contains(other *xmlElement) bool {
+	return other.start >= e.innerStart && other.end <= e.innerEnd
+}
+
+// attr returns the value of an attribute of the element.
+func (e *xmlElement) attr(name string) string {
+	for _, attr := range e.attrs {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+
+	return ""
+}
+
+// parseXMLElements returns every element of the document in document order.
+func parseXMLElements(config string) ([]*xmlElement, error) {
+	var (
+		elements []*xmlElement
+		stack    []*xmlElement
+		path     []string
+	)
+	// encoding/xml only supports XML 1.0 while Jenkins writes XML 1.1 declarations. The replacement keeps the
+	// document length, so offsets still point into the original document.
+	data := strings.Replace(config, "version='1.1'", "version='1.0'", 1)
+	data = strings.Replace(data, `version="1.1"`, `version="1.0"`, 1)
+	dec := xml.NewDecoder(strings.NewReader(data))
+	for {
+		offset := dec.InputOffset()
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			path = append(path, t.Name.Local)
+			element := &xmlElement{
+				path:       slices.Clone(path),
+				attrs:      t.Attr,
+				start:      offset,
+				innerStart: dec.InputOffset(),
+			}
+			elements = append(elements, element)
+			stack = append(stack, element)
+		case xml.EndElement:
+			element := stack[len(stack)-1]
+			element.innerEnd = offset
+			element.end = dec.InputOffset()
+			stack = stack[:len(stack)-1]
+			path = path[:len(path)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += string(t)
+			}
+		}
+	}
+
+	return elements, nil
+}
+
+// escapeXML escapes a value for use as XML character data.
+func escapeXML(value string) string {
+	var sb strings.Builder
+	_ = xml.EscapeText(&sb, []byte(value))
+	return sb.String()
+}
@@ -0,0 +1,135 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/conductorone/baton-sdk/pkg/uhttp"
+)
+
+// GET - http://{baseurl}/{job/folder/}credentials/store/{system|folder}/api/json?tree=domains[urlName,displayName,description,global]
+// GET - http://{baseurl}/{job/folder/}credentials/store/{system|folder}/domain/{domain}/api/json?tree=credentials[id,displayName,typeName,description,fullName]
+const (
+	credentialStore  = "%scredentials/store/%s/api/json?tree=domains[urlName,displayName,description,global]"
+	credentialDomain = "%scredentials/store/%s/domain/%s/api/json?tree=credentials[id,displayName,typeName,description,fullName]"
+)
+
+// Names of the credential stores of the Jenkins instance and of folders.
+const (
+	SystemStore = "system"
+	FolderStore = "folder"
+)
+
+// GlobalDomain is the URL name of the global domain, which every credential store has.
+const GlobalDomain = "_"
+
+// systemCredentialScopesScript prints, as JSON, the scope of every credential of the system store keyed by
+// domain URL name and credential ID. Scopes are not part of the REST API.
+const systemCredentialScopesScript = `
+import groovy.json.JsonOutput
+import com.cloudbees.plugins.credentials.SystemCredentialsProvider
+import com.cloudbees.plugins.credentials.common.IdCredentials
+
+def scopes = [:]
+SystemCredentialsProvider.getInstance().getDomainCredentialsMap().each { domain, credentials ->
+  credentials.findAll { it instanceof IdCredentials }.each {
+    scopes[(domain.getName() ?: '_') + '/' + it.getId()] = it.getScope()?.name()
+  }
+}
+println(JsonOutput.toJson(scopes))
+`
+
+// credentialStorePath returns the URL path prefix and the name of the credential store of a folder, or of the
+// system store when folder is empty.
+func credentialStorePath(folder string) (string, string) {
+	if folder == "" {
+		return "", SystemStore
+	}
+
+	return JobPath(folder), FolderStore
+}
+
+// getCredentialsJSON reads a Credentials plugin endpoint. It reports false when the endpoint does not exist,
+// which is the case when the plugin is not installed.
+func (d *JenkinsClient) getCredentialsJSON(ctx context.Context, apiUrl string, data interface{}) (bool, error) {
+	req, endpointUrl, err := getRequest(ctx, d, d.baseUrl, apiUrl)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := d.httpClient.Do(req, uhttp.WithJSONResponse(data))
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return false, nil
+	}
+
+	if err != nil {
+		return false, getCustomError(err, resp, endpointUrl)
+	}
+
+	defer resp.Body.Close()
+
+	return true, nil
+}
+
+// GetCredentialDomains
+// Get the domains of the credential store of a folder, or of the system store when folder is empty, sorted by
+// URL name. No domain is returned when the store does not exist.
+func (d *JenkinsClient) GetCredentialDomains(ctx context.Context, folder string) ([]CredentialDomain, error) {
+	var storeData CredentialStoreAPIData
+	path, store := credentialStorePath(folder)
+	found, err := d.getCredentialsJSON(ctx, fmt.Sprintf(credentialStore, path, store), &storeData)
+	if err != nil || !found {
+		return nil, err
+	}
+
+	var domains []CredentialDomain
+	for urlName, domain := range storeData.Domains {
+		if domain.URLName == "" {
+			domain.URLName = urlName
+		}
+		domains = append(domains, domain)
+	}
+
+	sort.Slice(domains, func(i, j int) bool {
+		return domains[i].URLName < domains[j].URLName
+	})
+
+	return domains, nil
+}
+
+// GetCredentials
+// Get the credentials of a domain of the credential store of a folder, or of the system store when folder is empty.
+// Secrets are never returned.
+func (d *JenkinsClient) GetCredentials(ctx context.Context, folder, domain string) ([]Credential, error) {
+	var domainData CredentialDomainAPIData
+	path, store := credentialStorePath(folder)
+	_, err := d.getCredentialsJSON(ctx, fmt.Sprintf(credentialDomain, path, store, url.PathEscape(domain)), &domainData)
+	if err != nil {
+		return nil, err
+	}
+
+	return domainData.Credentials, nil
+}
+
+// GetSystemCredentialScopes
+// Get the scope, GLOBAL or SYSTEM, of every credential of the system store keyed by domain URL name and
+// credential ID, e.g. _/deploy-key.
+func (d *JenkinsClient) GetSystemCredentialScopes(ctx context.Context) (map[string]string, error) {
+	var scopes map[string]string
+	output, err := d.RunScript(ctx, systemCredentialScopesScript)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &scopes); err != nil {
+		return nil, fmt.Errorf("error reading credential scopes: %w: %s", err, output)
+	}
+
+	return scopes, nil
+}
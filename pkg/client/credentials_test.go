@@ -0,0 +1,44 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/job/team/credentials/store/folder/api/json":
+			_, _ = w.Write([]byte(`{"domains":{"_":{"urlName":"_","displayName":"Global credentials (unrestricted)","global":true},"aws":{"displayName":"AWS"}}}`))
+		case "/job/team/credentials/store/folder/domain/aws/api/json":
+			_, _ = w.Write([]byte(`{"credentials":[{"id":"deploy","displayName":"deploy/******","typeName":"Username with password","description":"Deploy user"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	cli, err := New(ctx, server.URL, NewClient().WithUser("admin").WithPassword("admin"))
+	assert.Nil(t, err)
+
+	domains, err := cli.GetCredentialDomains(ctx, "team")
+	assert.Nil(t, err)
+	assert.Len(t, domains, 2)
+	assert.Equal(t, GlobalDomain, domains[0].URLName)
+	assert.Equal(t, "aws", domains[1].URLName)
+
+	credentials, err := cli.GetCredentials(ctx, "team", "aws")
+	assert.Nil(t, err)
+	assert.Equal(t, []Credential{{ID: "deploy", DisplayName: "deploy/******", TypeName: "Username with password", Description: "Deploy user"}}, credentials)
+
+	// The system store does not exist without the Credentials plugin.
+	domains, err = cli.GetCredentialDomains(ctx, "")
+	assert.Nil(t, err)
+	assert.Empty(t, domains)
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/conductorone/baton-sdk/pkg/uhttp"
+)
+
+// GET - http://{baseurl}/crumbIssuer/api/json
+const crumbIssuer = "crumbIssuer/api/json"
+
+// WithCrumb adds the CSRF crumb header, unless CSRF protection is disabled.
+func WithCrumb(crumb *CrumbAPIData) uhttp.RequestOption {
+	return func() (io.ReadWriter, map[string]string, error) {
+		if crumb == nil || crumb.CrumbRequestField == "" {
+			return nil, nil, nil
+		}
+
+		return nil, map[string]string{
+			crumb.CrumbRequestField: crumb.Crumb,
+		}, nil
+	}
+}
+
+// getCrumb returns the CSRF crumb of the session, fetching it when there is none yet or when refresh is set.
+// Crumbs are bound to the session cookie, so they are requested without going through the response cache.
+// https://www.jenkins.io/doc/book/security/csrf-protection/
+func (d *JenkinsClient) getCrumb(ctx context.Context, refresh bool) (*CrumbAPIData, error) {
+	d.crumbMu.Lock()
+	defer d.crumbMu.Unlock()
+	if d.crumb != nil && !refresh {
+		return d.crumb, nil
+	}
+
+	req, endpointUrl, err := getRequest(ctx, d, d.baseUrl, crumbIssuer)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.httpClient.HttpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var crumbData CrumbAPIData
+		if err := json.Unmarshal(body, &crumbData); err != nil {
+			return nil, err
+		}
+
+		d.crumb = &crumbData
+	case http.StatusNotFound:
+		// CSRF protection is disabled.
+		d.crumb = &CrumbAPIData{}
+	default:
+		return nil, &JenkinsError{
+			ErrorMessage: "unexpected crumb issuer response",
+			ErrorCode:    resp.StatusCode,
+			ErrorSummary: string(body),
+			ErrorLink:    endpointUrl,
+		}
+	}
+
+	return d.crumb, nil
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostRefreshesExpiredCrumb(t *testing.T) {
+	issued := 0
+	denied := 0
+	valid := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/crumbIssuer/api/json":
+			issued++
+			valid = fmt.Sprintf("crumb-%d", issued)
+			fmt.Fprintf(w, `{"crumb":%q,"crumbRequestField":"Jenkins-Crumb"}`, valid)
+		case "/role-strategy/strategy/assignUserRole":
+			// The first crumb expires before it is used.
+			if issued < 2 || r.Header.Get("Jenkins-Crumb") != valid {
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, "No valid crumb was included in the request")
+				return
+			}
+
+			assert.Nil(t, r.ParseForm())
+			assert.Equal(t, url.Values{"type": {GlobalRoles}, "roleName": {"admin"}, "user": {"jane"}}, r.PostForm)
+		case "/role-strategy/strategy/unassignUserRole":
+			denied++
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, "admin is missing the Overall/Administer permission")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	cli, err := New(ctx, server.URL, NewClient().WithUser("admin").WithPassword("admin"))
+	assert.Nil(t, err)
+
+	statusCode, err := cli.AssignUserRole(ctx, GlobalRoles, "admin", "jane")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+	assert.Equal(t, 2, issued)
+
+	// Permission failures are not retried.
+	statusCode, err = cli.UnassignUserRole(ctx, GlobalRoles, "admin", "jane")
+	assert.NotNil(t, err)
+	assert.Equal(t, http.StatusForbidden, statusCode)
+	assert.Equal(t, 1, denied)
+	assert.Equal(t, 2, issued)
+}
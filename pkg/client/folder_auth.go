@@ -0,0 +1,136 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/conductorone/baton-sdk/pkg/uhttp"
+)
+
+// GET - http://{baseurl}/folder-auth/getAllGlobalRoles
+// GET - http://{baseurl}/folder-auth/getAllFolderRoles
+// GET - http://{baseurl}/folder-auth/getAllAgentRoles
+// POST - http://{baseurl}/folder-auth/assignSidTo{Global|Folder|Agent}Role
+// POST - http://{baseurl}/folder-auth/removeSidFrom{Global|Folder|Agent}Role
+const (
+	allFolderAuthRoles          = "folder-auth/getAll%sRoles"
+	assignSidToFolderAuthRole   = "folder-auth/assignSidTo%sRole"
+	removeSidFromFolderAuthRole = "folder-auth/removeSidFrom%sRole"
+)
+
+// Role types of the Folder-based Authorization Strategy plugin, as used in its endpoint names.
+const (
+	FolderAuthGlobalRoles = "Global"
+	FolderAuthFolderRoles = "Folder"
+	FolderAuthAgentRoles  = "Agent"
+)
+
+// FolderAuthRoleTypes lists every Folder-based Authorization Strategy role type in the order they are synced.
+var FolderAuthRoleTypes = []string{FolderAuthGlobalRoles, FolderAuthFolderRoles, FolderAuthAgentRoles}
+
+// FolderAuthRole is a role of the Folder-based Authorization Strategy plugin. Folder roles apply to the listed
+// folders and everything inside them, agent roles to the listed agents. Sids do not say whether they refer to
+// a user or a group.
+type FolderAuthRole struct {
+	Type        string                 `json:"-"`
+	Name        string                 `json:"name,omitempty"`
+	Permissions []FolderAuthPermission `json:"permissions,omitempty"`
+	Sids        []string               `json:"sids,omitempty"`
+	Folders     []string               `json:"folderNames,omitempty"`
+	Agents      []string               `json:"agentNames,omitempty"`
+}
+
+// FolderAuthPermission is a permission ID of a Folder-based Authorization Strategy role. The plugin renders
+// permissions either as IDs or as objects holding the ID, depending on its version.
+type FolderAuthPermission string
+
+func (p *FolderAuthPermission) UnmarshalJSON(data []byte) error {
+	var id string
+	if err := json.Unmarshal(data, &id); err == nil {
+		*p = FolderAuthPermission(id)
+		return nil
+	}
+
+	var permission struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(data, &permission); err != nil {
+		return err
+	}
+
+	*p = FolderAuthPermission(permission.ID)
+
+	return nil
+}
+
+// PermissionIDs returns the IDs of the permissions of the role.
+func (r FolderAuthRole) PermissionIDs() []string {
+	var ids []string
+	for _, permission := range r.Permissions {
+		ids = append(ids, string(permission))
+	}
+
+	return ids
+}
+
+// GetFolderAuthRoles
+// Get the Folder-based Authorization Strategy roles of a type: Global, Folder or Agent.
+func (d *JenkinsClient) GetFolderAuthRoles(ctx context.Context, roleType string) ([]FolderAuthRole, error) {
+	var roles []FolderAuthRole
+	req, endpointUrl, err := getRequest(ctx, d, d.baseUrl, fmt.Sprintf(allFolderAuthRoles, roleType))
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := d.httpClient.Do(req, uhttp.WithJSONResponse(&roles))
+	if err != nil {
+		return nil, getCustomError(err, resp, endpointUrl)
+	}
+
+	defer resp.Body.Close()
+	for i := range roles {
+		roles[i].Type = roleType
+	}
+
+	return roles, nil
+}
+
+// GetAllFolderAuthRoles
+// Get the Folder-based Authorization Strategy roles of every type.
+func (d *JenkinsClient) GetAllFolderAuthRoles(ctx context.Context) ([]FolderAuthRole, error) {
+	var roles []FolderAuthRole
+	for _, roleType := range FolderAuthRoleTypes {
+		typeRoles, err := d.GetFolderAuthRoles(ctx, roleType)
+		if err != nil {
+			return nil, err
+		}
+
+		roles = append(roles, typeRoles...)
+	}
+
+	return roles, nil
+}
+
+// AssignSidToFolderAuthRole
+// Assign a user or group sid to a Folder-based Authorization Strategy role.
+func (d *JenkinsClient) AssignSidToFolderAuthRole(ctx context.Context, roleType, roleName, sid string) (int, error) {
+	form := url.Values{
+		"roleName": {roleName},
+		"sid":      {sid},
+	}
+
+	return d.postForm(ctx, fmt.Sprintf(assignSidToFolderAuthRole, roleType), form)
+}
+
+// RemoveSidFromFolderAuthRole
+// Remove a user or group sid from a Folder-based Authorization Strategy role.
+func (d *JenkinsClient) RemoveSidFromFolderAuthRole(ctx context.Context, roleType, roleName, sid string) (int, error) {
+	form := url.Values{
+		"roleName": {roleName},
+		"sid":      {sid},
+	}
+
+	return d.postForm(ctx, fmt.Sprintf(removeSidFromFolderAuthRole, roleType), form)
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFolderAuthRoles(t *testing.T) {
+	var assigned []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/folder-auth/getAllFolderRoles":
+			_, _ = w.Write([]byte(`[{"name":"deployers","permissions":[{"id":"hudson.model.Item.Build"},"hudson.model.Item.Read"],"sids":["jane"],"folderNames":["team"]}]`))
+		case "/folder-auth/assignSidToFolderRole":
+			assert.Nil(t, r.ParseForm())
+			assigned = append(assigned, r.PostForm.Get("roleName")+":"+r.PostForm.Get("sid"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	cli, err := New(ctx, server.URL, NewClient().WithUser("admin").WithPassword("admin"))
+	assert.Nil(t, err)
+
+	roles, err := cli.GetFolderAuthRoles(ctx, FolderAuthFolderRoles)
+	assert.Nil(t, err)
+	assert.Len(t, roles, 1)
+	assert.Equal(t, FolderAuthFolderRoles, roles[0].Type)
+	assert.Equal(t, []string{"hudson.model.Item.Build", "hudson.model.Item.Read"}, roles[0].PermissionIDs())
+	assert.Equal(t, []string{"team"}, roles[0].Folders)
+
+	_, err = cli.AssignSidToFolderAuthRole(ctx, FolderAuthFolderRoles, "deployers", "bob")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"deployers:bob"}, assigned)
+}
@@ -35,7 +35,7 @@ func TestJenkinsClient_GetJobs(t *testing.T) {
 	}
 
 	cli := getJenkinsClientForTesting()
-	nodes, err := cli.GetJobs(ctx)
+	nodes, err := cli.GetJobs(ctx, "")
 	assert.Nil(t, err)
 	assert.NotNil(t, nodes)
 }
@@ -74,7 +74,7 @@ func TestJenkinsClient_GetUsers(t *testing.T) {
 	}
 
 	cli := getJenkinsClientForTesting()
-	nodes, err := cli.GetUsers(ctx)
+	nodes, err := cli.GetUsers(ctx, 0, 100)
 	assert.Nil(t, err)
 	assert.NotNil(t, nodes)
 }
@@ -112,6 +112,17 @@ func TestJenkinsClient_GetAllRoles(t *testing.T) {
 	assert.NotNil(t, nodes)
 }
 
+func TestJenkinsClient_GetAllRoleDefinitions(t *testing.T) {
+	if userName == "" && (password == "" || token == "") {
+		t.Skip()
+	}
+
+	cli := getJenkinsClientForTesting()
+	roles, err := cli.GetAllRoleDefinitions(ctx)
+	assert.Nil(t, err)
+	assert.NotNil(t, roles)
+}
+
 func TestJenkinsClient_AssignUserRole(t *testing.T) {
 	if userName == "" && (password == "" || token == "") {
 		t.Skip()
@@ -120,7 +131,7 @@ func TestJenkinsClient_AssignUserRole(t *testing.T) {
 	roleName := "reviewer"
 	userName := "localuser"
 	cli := getJenkinsClientForTesting()
-	roles, err := cli.AssignUserRole(ctx, roleName, userName)
+	roles, err := cli.AssignUserRole(ctx, GlobalRoles, roleName, userName)
 	assert.Nil(t, err)
 	assert.NotNil(t, roles)
 }
@@ -133,7 +144,7 @@ func TestJenkinsClient_AssignGroupRole(t *testing.T) {
 	roleName := "builder"
 	groupName := "authenticated"
 	cli := getJenkinsClientForTesting()
-	roles, err := cli.AssignGroupRole(ctx, roleName, groupName)
+	roles, err := cli.AssignGroupRole(ctx, GlobalRoles, roleName, groupName)
 	assert.Nil(t, err)
 	assert.NotNil(t, roles)
 }
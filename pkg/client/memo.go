@@ -0,0 +1,45 @@
+package client
+
+import (
+	"sync"
+	"time"
+)
+
+// memoTTL bounds how long a memoized value is reused, so that changes made outside the connector are picked up.
+const memoTTL = 5 * time.Minute
+
+// memo keeps a value read through several requests, like the definitions of every role, so that it is read once
+// per sync rather than once per resource. It is reset whenever the client changes the Jenkins configuration.
+type memo[T any] struct {
+	mu      sync.Mutex
+	value   T
+	expires time.Time
+}
+
+// get returns the memoized value, loading it when there is none or when it has expired.
+func (m *memo[T]) get(load func() (T, error)) (T, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if time.Now().Before(m.expires) {
+		return m.value, nil
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	m.value = value
+	m.expires = time.Now().Add(memoTTL)
+
+	return value, nil
+}
+
+// reset drops the memoized value.
+func (m *memo[T]) reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var zero T
+	m.value = zero
+	m.expires = time.Time{}
+}
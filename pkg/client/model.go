@@ -9,6 +9,7 @@ type Computer struct {
 	Class               string           `json:"_class,omitempty"`
 	AssignedLabels      []AssignedLabels `json:"assignedLabels,omitempty"`
 	Description         string           `json:"description,omitempty"`
+	Name                string           `json:"name"`
 	DisplayName         string           `json:"displayName,omitempty"`
 	Idle                bool             `json:"idle,omitempty"`
 	ManualLaunchAllowed bool             `json:"manualLaunchAllowed,omitempty"`
@@ -26,9 +27,16 @@ type JobsAPIData struct {
 type Job struct {
 	Class     string `json:"_class,omitempty"`
 	Name      string `json:"name,omitempty"`
+	FullName  string `json:"fullName,omitempty"`
 	URL       string `json:"url,omitempty"`
 	Buildable bool   `json:"buildable,omitempty"`
 	Color     string `json:"color,omitempty"`
+	Jobs      []Job  `json:"jobs,omitempty"`
+}
+
+// IsFolder reports whether the item contains other items, like folders and multibranch projects do.
+func (j Job) IsFolder() bool {
+	return j.Jobs != nil
 }
 
 type ViewsAPIData struct {
@@ -48,21 +56,80 @@ type UsersAPIData struct {
 }
 
 type Users struct {
-	LastChange interface{} `json:"lastChange,omitempty"`
+	LastChange int64       `json:"lastChange,omitempty"`
 	Project    interface{} `json:"project,omitempty"`
 	User       User        `json:"user,omitempty"`
 }
 
 type User struct {
-	AbsoluteURL string      `json:"absoluteUrl,omitempty"`
-	Description interface{} `json:"description,omitempty"`
-	FullName    string      `json:"fullName,omitempty"`
-	ID          string      `json:"id,omitempty"`
+	AbsoluteURL string         `json:"absoluteUrl,omitempty"`
+	Description string         `json:"description,omitempty"`
+	FullName    string         `json:"fullName,omitempty"`
+	ID          string         `json:"id,omitempty"`
+	Property    []UserProperty `json:"property,omitempty"`
+}
+
+// User properties telling what a user is.
+const (
+	// mailerUserProperty is the user property of the Mailer plugin holding the email address.
+	mailerUserProperty = "hudson.tasks.Mailer$UserProperty"
+	// localAccountProperty holds the password of the users of the Jenkins own user database.
+	localAccountProperty = "hudson.security.HudsonPrivateSecurityRealm$Details"
+	// lastGrantedAuthoritiesProperty is recorded when a user authenticates with the security realm.
+	lastGrantedAuthoritiesProperty = "jenkins.security.LastGrantedAuthoritiesProperty"
+)
+
+// Email returns the email address set by the Mailer plugin, if any.
+func (u User) Email() string {
+	for _, property := range u.Property {
+		if property.Class == mailerUserProperty {
+			return property.Address
+		}
+	}
+
+	return ""
+}
+
+// IsLocalAccount reports whether the user belongs to the Jenkins own user database.
+func (u User) IsLocalAccount() bool {
+	for _, property := range u.Property {
+		if property.Class == localAccountProperty {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasAccount reports whether the user can log in, either because it belongs to the Jenkins own user database
+// or because it has authenticated with the security realm. Other users are only known as SCM commit authors.
+func (u User) HasAccount() bool {
+	for _, property := range u.Property {
+		if property.Class == localAccountProperty || property.Class == lastGrantedAuthoritiesProperty {
+			return true
+		}
+	}
+
+	return false
+}
+
+type UserProperty struct {
+	Class   string `json:"_class,omitempty"`
+	Address string `json:"address,omitempty"`
 }
 
 type RolesAPIData struct {
-	RoleName   string `json:"RoleName,omitempty"`
-	RoleDetail []Role `json:"roles,omitempty"`
+	RoleName    string   `json:"RoleName,omitempty"`
+	RoleType    string   `json:"RoleType,omitempty"`
+	RoleDetail  []Role   `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	Pattern     string   `json:"pattern,omitempty"`
+}
+
+type RoleAPIData struct {
+	PermissionIds map[string]bool `json:"permissionIds,omitempty"`
+	Sids          []any           `json:"sids,omitempty"`
+	Pattern       string          `json:"pattern,omitempty"`
 }
 
 type Role struct {
@@ -81,3 +148,105 @@ type GroupsAPIData struct {
 	Class  string  `json:"_class,omitempty"`
 	Groups []Group `json:"groups,omitempty"`
 }
+
+type MatrixAPIData struct {
+	Permissions []Permission  `json:"permissions,omitempty"`
+	Entries     []MatrixEntry `json:"entries,omitempty"`
+}
+
+type Permission struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+type MatrixEntry struct {
+	Permission string `json:"permission,omitempty"`
+	Sid        string `json:"sid,omitempty"`
+	Type       string `json:"type,omitempty"`
+}
+
+type ItemMatrix struct {
+	InheritanceStrategy string        `json:"inheritanceStrategy,omitempty"`
+	Entries             []MatrixEntry `json:"entries,omitempty"`
+}
+
+type SecurityAPIData struct {
+	AuthorizationStrategy string `json:"authorizationStrategy,omitempty"`
+	SecurityRealm         string `json:"securityRealm,omitempty"`
+}
+
+type WhoAmIAPIData struct {
+	Class         string   `json:"_class,omitempty"`
+	Name          string   `json:"name,omitempty"`
+	Anonymous     bool     `json:"anonymous,omitempty"`
+	Authenticated bool     `json:"authenticated,omitempty"`
+	Authorities   []string `json:"authorities,omitempty"`
+	// Version is the Jenkins version, read from the X-Jenkins response header.
+	Version string `json:"-"`
+}
+
+type PluginsAPIData struct {
+	Class   string   `json:"_class,omitempty"`
+	Plugins []Plugin `json:"plugins,omitempty"`
+}
+
+type Plugin struct {
+	ShortName string `json:"shortName,omitempty"`
+	Version   string `json:"version,omitempty"`
+	Active    bool   `json:"active,omitempty"`
+	Enabled   bool   `json:"enabled,omitempty"`
+}
+
+type CrumbAPIData struct {
+	Class             string `json:"_class,omitempty"`
+	Crumb             string `json:"crumb,omitempty"`
+	CrumbRequestField string `json:"crumbRequestField,omitempty"`
+}
+
+type TokenAPIData struct {
+	Status string `json:"status,omitempty"`
+	Data   Token  `json:"data,omitempty"`
+}
+
+type Token struct {
+	TokenName  string `json:"tokenName,omitempty"`
+	TokenUuid  string `json:"tokenUuid,omitempty"`
+	TokenValue string `json:"tokenValue,omitempty"`
+}
+
+// TokenInfo describes an API token. Dates are in milliseconds since the epoch and are zero when unknown.
+type TokenInfo struct {
+	UUID         string `json:"uuid,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Legacy       bool   `json:"legacy,omitempty"`
+	CreationDate int64  `json:"creationDate,omitempty"`
+	UseCounter   int    `json:"useCounter,omitempty"`
+	LastUseDate  int64  `json:"lastUseDate,omitempty"`
+}
+
+type CredentialStoreAPIData struct {
+	Class   string                      `json:"_class,omitempty"`
+	Domains map[string]CredentialDomain `json:"domains,omitempty"`
+}
+
+// CredentialDomain is a domain of a credential store. The global domain has the "_" URL name.
+type CredentialDomain struct {
+	URLName     string `json:"urlName,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	Description string `json:"description,omitempty"`
+	Global      bool   `json:"global,omitempty"`
+}
+
+type CredentialDomainAPIData struct {
+	Class       string       `json:"_class,omitempty"`
+	Credentials []Credential `json:"credentials,omitempty"`
+}
+
+// Credential describes a credential of the Credentials plugin, without its secret.
+type Credential struct {
+	ID          string `json:"id,omitempty"`
+	DisplayName string `json:"displayName,omitempty"`
+	TypeName    string `json:"typeName,omitempty"`
+	Description string `json:"description,omitempty"`
+	FullName    string `json:"fullName,omitempty"`
+}
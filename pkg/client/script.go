@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// POST - http://{baseurl}/scriptText
+const scriptText = "scriptText"
+
+// RunScript
+// Run a Groovy script through the script console and return its output.
+// Requires the Overall/Administer permission.
+// https://www.jenkins.io/doc/book/managing/script-console/#remote-access
+func (d *JenkinsClient) RunScript(ctx context.Context, script string) (string, error) {
+	form := url.Values{
+		"script": {script},
+	}
+	resp, endpointUrl, err := d.post(ctx, scriptText, WithBody(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+
+	defer resp.Body.Close()
+	output, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", &JenkinsError{
+			ErrorMessage: "unexpected script console response",
+			ErrorCode:    resp.StatusCode,
+			ErrorSummary: string(output),
+			ErrorLink:    endpointUrl,
+		}
+	}
+
+	return string(output), nil
+}
+
+// runUpdateScript runs a Groovy script changing the Jenkins configuration and clears the response cache once
+// it succeeded. Scripts which only read are run with RunScript and leave the cache alone.
+func (d *JenkinsClient) runUpdateScript(ctx context.Context, script string) (string, error) {
+	output, err := d.RunScript(ctx, script)
+	if err != nil {
+		return "", err
+	}
+
+	d.clearCaches(ctx)
+
+	return output, nil
+}
+
+// groovyString quotes a value as a single quoted Groovy string literal, which is never interpolated.
+func groovyString(value string) string {
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		`'`, `\'`,
+		"\n", `\n`,
+		"\r", `\r`,
+	)
+
+	return "'" + replacer.Replace(value) + "'"
+}
@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/conductorone/baton-sdk/pkg/uhttp"
+)
+
+// GET - http://{baseurl}/securityRealm/user/{userId}/api/json
+const securityRealmUser = "securityRealm/user/%s/api/json"
+
+// securityScript prints the classes of the configured authorization strategy and security realm as JSON.
+const securityScript = `
+import groovy.json.JsonOutput
+import jenkins.model.Jenkins
+
+def jenkins = Jenkins.get()
+println(JsonOutput.toJson([
+  authorizationStrategy: jenkins.getAuthorizationStrategy().getClass().getName(),
+  securityRealm: jenkins.getSecurityRealm().getClass().getName(),
+]))
+`
+
+// GetSecurityConfiguration
+// Get the authorization strategy and the security realm configured on the controller.
+func (d *JenkinsClient) GetSecurityConfiguration(ctx context.Context) (*SecurityAPIData, error) {
+	var securityData SecurityAPIData
+	output, err := d.RunScript(ctx, securityScript)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &securityData); err != nil {
+		return nil, fmt.Errorf("error reading the security configuration: %w: %s", err, output)
+	}
+
+	return &securityData, nil
+}
+
+// IsLocalSecurityRealm
+// Report whether the controller uses the Jenkins' own user database. Only that realm serves its users under
+// securityRealm/user, so the user of the credentials is looked up there.
+func (d *JenkinsClient) IsLocalSecurityRealm(ctx context.Context, userId string) (bool, error) {
+	var userData User
+	req, endpointUrl, err := getRequest(ctx, d, d.baseUrl, fmt.Sprintf(securityRealmUser, url.PathEscape(userId)))
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := d.httpClient.Do(req, uhttp.WithJSONResponse(&userData))
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		resp.Body.Close()
+		return false, nil
+	}
+
+	if err != nil {
+		return false, getCustomError(err, resp, endpointUrl)
+	}
+
+	defer resp.Body.Close()
+
+	return true, nil
+}
@@ -0,0 +1,35 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsLocalSecurityRealm(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/securityRealm/user/admin/api/json" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"admin","fullName":"Admin"}`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	cli, err := New(ctx, server.URL, NewClient().WithUser("admin").WithPassword("admin"))
+	assert.Nil(t, err)
+
+	local, err := cli.IsLocalSecurityRealm(ctx, "admin")
+	assert.Nil(t, err)
+	assert.True(t, local)
+
+	local, err = cli.IsLocalSecurityRealm(ctx, "ldap-user")
+	assert.Nil(t, err)
+	assert.False(t, local)
+}
@@ -0,0 +1,150 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// POST - http://{baseurl}/user/{userId}/descriptorByName/jenkins.security.ApiTokenProperty/generateNewToken
+// POST - http://{baseurl}/user/{userId}/descriptorByName/jenkins.security.ApiTokenProperty/revokeAllExcept
+const (
+	generateNewToken = "user/%s/descriptorByName/jenkins.security.ApiTokenProperty/generateNewToken"
+	revokeAllExcept  = "user/%s/descriptorByName/jenkins.security.ApiTokenProperty/revokeAllExcept"
+)
+
+// rotateTokenScript generates a named API token for a user and revokes every other token, legacy one included.
+// Used when Jenkins does not let administrators generate tokens for other users, which is the default.
+const rotateTokenScript = `
+import groovy.json.JsonOutput
+import hudson.model.User
+import jenkins.security.ApiTokenProperty
+
+def user = User.getById(%s, false)
+if (user == null) {
+  throw new IllegalArgumentException("unknown user")
+}
+
+def property = user.getProperty(ApiTokenProperty.class)
+if (property == null) {
+  property = new ApiTokenProperty()
+  user.addProperty(property)
+}
+
+def token = property.getTokenStore().generateNewToken(%s)
+property.getTokenStore().revokeAllTokensExcept(token.tokenUuid)
+user.save()
+println(JsonOutput.toJson([tokenName: %s, tokenUuid: token.tokenUuid, tokenValue: token.plainValue]))
+`
+
+// userTokensScript prints the API tokens of a user with their usage statistics as JSON. Tokens are not part
+// of the REST API.
+const userTokensScript = `
+import groovy.json.JsonOutput
+import hudson.model.User
+import jenkins.security.ApiTokenProperty
+
+def tokens = []
+def property = User.getById(%s, false)?.getProperty(ApiTokenProperty.class)
+property?.getTokenList()?.each { token ->
+  tokens << [
+    uuid: token.uuid,
+    name: token.name,
+    legacy: token.isLegacy,
+    creationDate: token.creationDate?.getTime(),
+    useCounter: token.useCounter,
+    lastUseDate: token.lastUseDate?.getTime(),
+  ]
+}
+println(JsonOutput.toJson(tokens))
+`
+
+// GetUserTokens
+// Get the API tokens of a user, without their values.
+func (d *JenkinsClient) GetUserTokens(ctx context.Context, userId string) ([]TokenInfo, error) {
+	var tokens []TokenInfo
+	output, err := d.RunScript(ctx, fmt.Sprintf(userTokensScript, groovyString(userId)))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &tokens); err != nil {
+		return nil, fmt.Errorf("error reading the tokens of %s: %w: %s", userId, err, output)
+	}
+
+	return tokens, nil
+}
+
+// RotateToken
+// Generate a named API token for a user and revoke every other token of the user, legacy one included.
+// The token is generated through the REST API, or through the script console when Jenkins refuses to let
+// administrators generate tokens for other users.
+func (d *JenkinsClient) RotateToken(ctx context.Context, userId, tokenName string) (*Token, error) {
+	token, err := d.generateNewToken(ctx, userId, tokenName)
+	if err != nil {
+		var jenkinsErr *JenkinsError
+		if !errors.As(err, &jenkinsErr) || jenkinsErr.ErrorCode != http.StatusForbidden {
+			return nil, err
+		}
+
+		return d.rotateTokenWithScript(ctx, userId, tokenName)
+	}
+
+	form := url.Values{
+		"tokenUuid": {token.TokenUuid},
+	}
+	_, err = d.postForm(ctx, fmt.Sprintf(revokeAllExcept, url.PathEscape(userId)), form)
+	if err != nil {
+		return nil, fmt.Errorf("token %s was generated but the other tokens were not revoked: %w", tokenName, err)
+	}
+
+	return token, nil
+}
+
+func (d *JenkinsClient) generateNewToken(ctx context.Context, userId, tokenName string) (*Token, error) {
+	var tokenData TokenAPIData
+	form := url.Values{
+		"newTokenName": {tokenName},
+	}
+	resp, endpointUrl, err := d.update(ctx, fmt.Sprintf(generateNewToken, url.PathEscape(userId)), WithBody(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(body, &tokenData); err != nil || tokenData.Status != "ok" {
+		return nil, &JenkinsError{
+			ErrorMessage: "unexpected token generation response",
+			ErrorCode:    resp.StatusCode,
+			ErrorSummary: string(body),
+			ErrorLink:    endpointUrl,
+		}
+	}
+
+	return &tokenData.Data, nil
+}
+
+func (d *JenkinsClient) rotateTokenWithScript(ctx context.Context, userId, tokenName string) (*Token, error) {
+	var token Token
+	script := fmt.Sprintf(rotateTokenScript, groovyString(userId), groovyString(tokenName), groovyString(tokenName))
+	output, err := d.runUpdateScript(ctx, script)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(strings.TrimSpace(output)), &token); err != nil {
+		return nil, fmt.Errorf("error reading the generated token: %w", err)
+	}
+
+	return &token, nil
+}
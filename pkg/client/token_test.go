@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotateToken(t *testing.T) {
+	var revoked []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Nil(t, r.ParseForm())
+		switch r.URL.Path {
+		case "/user/jane/descriptorByName/jenkins.security.ApiTokenProperty/generateNewToken":
+			_, _ = w.Write([]byte(`{"status":"ok","data":{"tokenName":"` + r.PostForm.Get("newTokenName") + `","tokenUuid":"uuid-1","tokenValue":"secret"}}`))
+		case "/user/jane/descriptorByName/jenkins.security.ApiTokenProperty/revokeAllExcept":
+			revoked = append(revoked, r.PostForm.Get("tokenUuid"))
+		case "/user/svc/descriptorByName/jenkins.security.ApiTokenProperty/generateNewToken":
+			w.WriteHeader(http.StatusForbidden)
+		case "/scriptText":
+			assert.True(t, strings.Contains(r.PostForm.Get("script"), "revokeAllTokensExcept"))
+			_, _ = w.Write([]byte(`{"tokenName":"rotated","tokenUuid":"uuid-2","tokenValue":"other"}` + "\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	cli, err := New(ctx, server.URL, NewClient().WithUser("admin").WithPassword("admin"))
+	assert.Nil(t, err)
+
+	token, err := cli.RotateToken(ctx, "jane", "rotated")
+	assert.Nil(t, err)
+	assert.Equal(t, &Token{TokenName: "rotated", TokenUuid: "uuid-1", TokenValue: "secret"}, token)
+	assert.Equal(t, []string{"uuid-1"}, revoked)
+
+	token, err = cli.RotateToken(ctx, "svc", "rotated")
+	assert.Nil(t, err)
+	assert.Equal(t, "other", token.TokenValue)
+}
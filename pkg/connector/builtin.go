@@ -0,0 +1,88 @@
+package connector
+
+import (
+	"context"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+// Built-in principals Jenkins defines regardless of the security realm. They cannot be created or deleted.
+const (
+	// anonymousUser is the user Jenkins uses for requests without credentials.
+	anonymousUser = "anonymous"
+	// authenticatedGroup is the group every logged in user belongs to.
+	authenticatedGroup = "authenticated"
+)
+
+// readPermission is the Overall/Read permission, required to see anything in Jenkins.
+const readPermission = "hudson.model.Hudson.Read"
+
+// isBuiltInSid reports whether a sid refers to one of the built-in principals.
+func isBuiltInSid(sid string) bool {
+	return sid == anonymousUser || sid == authenticatedGroup
+}
+
+// isAuthenticatedMember reports whether a user belongs to the authenticated group, which takes being able to log
+// in. People only known as SCM commit authors never are, whether they are synced or not.
+func isAuthenticatedMember(user client.User) bool {
+	return !isBuiltInSid(user.ID) && user.HasAccount()
+}
+
+// anonymousReadAllowed reports whether the authorization strategy grants Overall/Read to the anonymous user,
+// letting anyone who can reach the controller browse it without logging in.
+func anonymousReadAllowed(ctx context.Context, c *client.JenkinsClient, strategy string) (bool, error) {
+	entries, err := itemEntries(ctx, c, strategy, client.GlobalRoles, "")
+	if err != nil {
+		return false, err
+	}
+
+	for _, entry := range entries {
+		if isUserSid(entry.sid, anonymousUser) && entry.grantsPermission(readPermission) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// anonymousUserResource creates the resource of the anonymous user.
+func anonymousUserResource(anonymousRead bool, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	profile := map[string]interface{}{
+		"user_id":        anonymousUser,
+		"built_in":       true,
+		"anonymous_read": anonymousRead,
+	}
+
+	return rs.NewUserResource(
+		"Anonymous",
+		resourceTypeUser,
+		anonymousUser,
+		[]rs.UserTraitOption{
+			rs.WithUserProfile(profile),
+			rs.WithStatus(v2.UserTrait_Status_STATUS_ENABLED),
+			rs.WithAccountType(v2.UserTrait_ACCOUNT_TYPE_SYSTEM),
+		},
+		rs.WithParentResourceID(parentResourceID),
+		rs.WithDescription("Built-in user of every request made without logging in. It cannot be deleted."),
+	)
+}
+
+// authenticatedGroupResource creates the resource of the authenticated group.
+func authenticatedGroupResource(parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	profile := map[string]interface{}{
+		"group_id":   authenticatedGroup,
+		"group_name": "Authenticated Users",
+		"built_in":   true,
+	}
+
+	return rs.NewGroupResource(
+		"Authenticated Users",
+		resourceTypeGroup,
+		authenticatedGroup,
+		[]rs.GroupTraitOption{rs.WithGroupProfile(profile)},
+		rs.WithParentResourceID(parentResourceID),
+		rs.WithDescription("Built-in group of every user who has logged in. Granting it access grants everyone with an account. It cannot be deleted."),
+	)
+}
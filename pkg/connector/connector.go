@@ -2,7 +2,9 @@ package connector
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"slices"
 
 	"github.com/conductorone/baton-jenkins/pkg/client"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
@@ -10,20 +12,60 @@ import (
 	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
 )
 
+// Plugins providing resource types beyond the Jenkins core.
+const (
+	credentialsPlugin = "credentials"
+	foldersPlugin     = "cloudbees-folder"
+)
+
 type Connector struct {
-	client *client.JenkinsClient
+	client                *client.JenkinsClient
+	authorizationStrategy string
+	securityRealm         string
+	plugins               map[string]bool
+	scriptConsole         bool
+	usersSource           string
+	excludeSCMUsers       bool
 }
 
 // ResourceSyncers returns a ResourceSyncer for each resource type that should be synced from the upstream service.
+// Resource types provided by plugins are only synced when the plugin is active, API tokens only when they can be
+// read through the script console, and roles only with the authorization strategy defining them.
 func (d *Connector) ResourceSyncers(ctx context.Context) []connectorbuilder.ResourceSyncer {
-	return []connectorbuilder.ResourceSyncer{
-		newUserBuilder(d.client),
-		newJobBuilder(d.client),
-		newNodeBuilder(d.client),
+	syncers := []connectorbuilder.ResourceSyncer{
+		newUserBuilder(d.client, d.authorizationStrategy, d.securityRealm, d.usersSource, d.excludeSCMUsers),
+		newJobBuilder(d.client, d.authorizationStrategy),
+		newNodeBuilder(d.client, d.authorizationStrategy),
 		newViewBuilder(d.client),
-		newRoleBuilder(d.client),
-		newGroupBuilder(d.client),
+		newGroupBuilder(d.client, d.authorizationStrategy, d.securityRealm, d.usersSource),
+	}
+
+	if d.scriptConsole {
+		syncers = append(syncers, newTokenBuilder(d.client))
+	}
+
+	if pluginActive(d.plugins, foldersPlugin) {
+		syncers = append(syncers, newFolderBuilder(d.client, d.authorizationStrategy))
 	}
+
+	if pluginActive(d.plugins, credentialsPlugin) {
+		syncers = append(syncers,
+			newCredentialStoreBuilder(d.client, d.authorizationStrategy),
+			newCredentialDomainBuilder(d.client),
+			newCredentialBuilder(d.client, d.scriptConsole),
+		)
+	}
+
+	switch {
+	case isMatrixStrategy(d.authorizationStrategy):
+		syncers = append(syncers, newMatrixBuilder(d.client))
+	case d.authorizationStrategy == folderAuthStrategy:
+		syncers = append(syncers, newFolderAuthRoleBuilder(d.client))
+	case d.authorizationStrategy == roleStrategy:
+		syncers = append(syncers, newRoleBuilder(d.client))
+	}
+
+	return syncers
 }
 
 // Asset takes an input AssetRef and attempts to fetch it using the connector's authenticated http client
@@ -36,27 +78,62 @@ func (d *Connector) Asset(ctx context.Context, asset *v2.AssetRef) (string, io.R
 func (d *Connector) Metadata(ctx context.Context) (*v2.ConnectorMetadata, error) {
 	return &v2.ConnectorMetadata{
 		DisplayName: "Jenkins Connector",
-		Description: "Connector syncing users, roles, groups, nodes and jobs from Jenkins.",
+		Description: "Connector syncing users, roles, groups, nodes, folders and jobs from Jenkins.",
 	}, nil
 }
 
 // Validate is called to ensure that the connector is properly configured. It should exercise any API credentials
 // to be sure that they are valid.
 func (d *Connector) Validate(ctx context.Context) (annotations.Annotations, error) {
+	if !d.client.CheckCredentials() {
+		return nil, fmt.Errorf("jenkins-connector: a username with a password or token is required")
+	}
+
+	if err := validateAccess(ctx, d.client, d.authorizationStrategy, d.usersSource); err != nil {
+		return nil, err
+	}
+
 	return nil, nil
 }
 
 // New returns a new instance of the connector.
-func New(ctx context.Context, baseUrl string, jenkinsClient *client.JenkinsClient) (*Connector, error) {
+// The authorization strategy selects the backend used to read and provision permissions: role-strategy, matrix,
+// project-matrix or folder-auth. When it is empty, it is detected from the controller together with the security realm
+// and the active plugins.
+// When excludeSCMUsers is set, people only known as SCM commit authors are not synced as users. Users are listed
+// through the People API, or through the asynchronous one when asynchPeople is set.
+func New(ctx context.Context, baseUrl string, jenkinsClient *client.JenkinsClient, authorizationStrategy string, excludeSCMUsers, asynchPeople bool) (*Connector, error) {
 	var err error
+	security := &securityConfiguration{}
+	if authorizationStrategy != "" && !slices.Contains(authorizationStrategies, authorizationStrategy) {
+		return nil, fmt.Errorf("jenkins-connector: unsupported authorization strategy %s", authorizationStrategy)
+	}
+
 	if jenkinsClient.CheckCredentials() {
 		jenkinsClient, err = client.New(ctx, baseUrl, jenkinsClient)
 		if err != nil {
 			return nil, err
 		}
+
+		security, err = detectSecurity(ctx, jenkinsClient, authorizationStrategy)
+		if err != nil {
+			return nil, err
+		}
+
+		authorizationStrategy = security.authorizationStrategy
+	}
+
+	if authorizationStrategy == "" {
+		return nil, fmt.Errorf("jenkins-connector: credentials are required to detect the authorization strategy")
 	}
 
 	return &Connector{
-		client: jenkinsClient,
+		client:                jenkinsClient,
+		authorizationStrategy: authorizationStrategy,
+		securityRealm:         security.securityRealm,
+		plugins:               security.plugins,
+		scriptConsole:         security.scriptConsole,
+		usersSource:           usersSource(security.scriptConsole, asynchPeople),
+		excludeSCMUsers:       excludeSCMUsers,
 	}, nil
 }
@@ -0,0 +1,141 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// globalScope is the scope of credentials usable by jobs. Folder stores only hold credentials of this scope,
+// while the system store may also hold SYSTEM credentials, only usable by Jenkins itself.
+const globalScope = "GLOBAL"
+
+type credentialBuilder struct {
+	resourceType  *v2.ResourceType
+	client        *client.JenkinsClient
+	scriptConsole bool
+}
+
+// credentialProfile returns the kind, scope, domain and owning folder of a credential. The scope is left out
+// when unknown, the folder for credentials of the system store.
+func credentialProfile(credential client.Credential, scope string, domain client.CredentialDomain, folder string) map[string]interface{} {
+	profile := map[string]interface{}{
+		"credential_id": credential.ID,
+		"kind":          credential.TypeName,
+		"domain":        domain.URLName,
+		"domain_name":   domain.DisplayName,
+	}
+	if scope != "" {
+		profile["scope"] = scope
+	}
+
+	if folder != "" {
+		profile["folder"] = folder
+	}
+
+	return profile
+}
+
+// Create a new connector resource for a credential of a credential store. Only the credential metadata is read,
+// never its secret.
+func credentialResource(ctx context.Context, credential client.Credential, scope string, domain client.CredentialDomain, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	folder := credentialStoreFolder(parentResourceID.Resource)
+	profile, err := structpb.NewStruct(credentialProfile(credential, scope, domain, folder))
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := rs.NewResource(
+		credential.ID,
+		resourceTypeCredential,
+		fmt.Sprintf("%s/%s/%s", parentResourceID.Resource, domain.URLName, credential.ID),
+		rs.WithParentResourceID(parentResourceID),
+		rs.WithDescription(credential.Description),
+		rs.WithAnnotation(profile),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (c *credentialBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return c.resourceType
+}
+
+// credentialScopes returns the scope of the credentials of a store keyed by domain URL name and credential ID.
+// Scopes of the system store are read through the script console, when it is available.
+func (c *credentialBuilder) credentialScopes(ctx context.Context, folder string) (map[string]string, error) {
+	if folder != "" || !c.scriptConsole {
+		return nil, nil
+	}
+
+	return c.client.GetSystemCredentialScopes(ctx)
+}
+
+// List returns the credentials of every domain of a credential store, without their secrets. Credentials are
+// only listed as children of credential stores.
+func (c *credentialBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	var rv []*v2.Resource
+	if parentResourceID == nil || parentResourceID.ResourceType != resourceTypeCredentialStore.Id {
+		return nil, "", nil, nil
+	}
+
+	folder := credentialStoreFolder(parentResourceID.Resource)
+	domains, err := c.client.GetCredentialDomains(ctx, folder)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	scopes, err := c.credentialScopes(ctx, folder)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	for _, domain := range domains {
+		credentials, err := c.client.GetCredentials(ctx, folder, domain.URLName)
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		for _, credential := range credentials {
+			scope := scopes[fmt.Sprintf("%s/%s", domain.URLName, credential.ID)]
+			if folder != "" {
+				scope = globalScope
+			}
+
+			nr, err := credentialResource(ctx, credential, scope, domain, parentResourceID)
+			if err != nil {
+				return nil, "", nil, err
+			}
+			rv = append(rv, nr)
+		}
+	}
+
+	return rv, "", nil, nil
+}
+
+// Entitlements always returns an empty slice for credentials.
+func (c *credentialBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	return nil, "", nil, nil
+}
+
+// Grants always returns an empty slice for credentials since they don't have any entitlements.
+func (c *credentialBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	return nil, "", nil, nil
+}
+
+func newCredentialBuilder(client *client.JenkinsClient, scriptConsole bool) *credentialBuilder {
+	return &credentialBuilder{
+		resourceType:  resourceTypeCredential,
+		client:        client,
+		scriptConsole: scriptConsole,
+	}
+}
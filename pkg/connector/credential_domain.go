@@ -0,0 +1,77 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+type credentialDomainBuilder struct {
+	resourceType *v2.ResourceType
+	client       *client.JenkinsClient
+}
+
+// Create a new connector resource for a domain of a credential store.
+func credentialDomainResource(ctx context.Context, domain client.CredentialDomain, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	ret, err := rs.NewResource(
+		domain.DisplayName,
+		resourceTypeCredentialDomain,
+		fmt.Sprintf("%s/%s", parentResourceID.Resource, domain.URLName),
+		rs.WithParentResourceID(parentResourceID),
+		rs.WithDescription(domain.Description),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (d *credentialDomainBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return d.resourceType
+}
+
+// List returns the domains of a credential store. Domains are only listed as children of credential stores.
+func (d *credentialDomainBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	var rv []*v2.Resource
+	if parentResourceID == nil || parentResourceID.ResourceType != resourceTypeCredentialStore.Id {
+		return nil, "", nil, nil
+	}
+
+	domains, err := d.client.GetCredentialDomains(ctx, credentialStoreFolder(parentResourceID.Resource))
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	for _, domain := range domains {
+		nr, err := credentialDomainResource(ctx, domain, parentResourceID)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		rv = append(rv, nr)
+	}
+
+	return rv, "", nil, nil
+}
+
+// Entitlements always returns an empty slice for credential domains.
+func (d *credentialDomainBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	return nil, "", nil, nil
+}
+
+// Grants always returns an empty slice for credential domains since they don't have any entitlements.
+func (d *credentialDomainBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	return nil, "", nil, nil
+}
+
+func newCredentialDomainBuilder(client *client.JenkinsClient) *credentialDomainBuilder {
+	return &credentialDomainBuilder{
+		resourceType: resourceTypeCredentialDomain,
+		client:       client,
+	}
+}
@@ -0,0 +1,142 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+// folderStorePrefix prefixes the resource ID of folder credential stores, which are followed by the folder full name.
+const folderStorePrefix = client.FolderStore + ":"
+
+type credentialStoreBuilder struct {
+	resourceType          *v2.ResourceType
+	client                *client.JenkinsClient
+	authorizationStrategy string
+}
+
+// credentialStoreID returns the resource ID of the credential store of a folder, or of the system store when
+// folder is empty.
+func credentialStoreID(folder string) string {
+	if folder == "" {
+		return client.SystemStore
+	}
+
+	return folderStorePrefix + folder
+}
+
+// credentialStoreFolder returns the full name of the folder owning a credential store, or an empty string for
+// the system store.
+func credentialStoreFolder(storeID string) string {
+	folder, ok := strings.CutPrefix(storeID, folderStorePrefix)
+	if !ok {
+		return ""
+	}
+
+	return folder
+}
+
+// Create a new connector resource for the credential store of a folder, or for the system store when folder is empty.
+func credentialStoreResource(ctx context.Context, folder string, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	displayName := "System"
+	store := client.SystemStore
+	if folder != "" {
+		displayName = folder
+		store = client.FolderStore
+	}
+
+	profile := map[string]interface{}{
+		"store":  store,
+		"folder": folder,
+	}
+
+	ret, err := rs.NewGroupResource(
+		fmt.Sprintf("%s Credentials", displayName),
+		resourceTypeCredentialStore,
+		credentialStoreID(folder),
+		[]rs.GroupTraitOption{rs.WithGroupProfile(profile)},
+		rs.WithParentResourceID(parentResourceID),
+		rs.WithAnnotation(
+			&v2.ChildResourceType{ResourceTypeId: resourceTypeCredentialDomain.Id},
+			&v2.ChildResourceType{ResourceTypeId: resourceTypeCredential.Id},
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (s *credentialStoreBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return s.resourceType
+}
+
+// List returns the system credential store, or the credential store of a folder when the parent resource is a
+// folder. Nothing is returned when the Credentials plugin is not installed.
+func (s *credentialStoreBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	if parentResourceID != nil && parentResourceID.ResourceType != resourceTypeFolder.Id {
+		return nil, "", nil, nil
+	}
+
+	folder := folderName(parentResourceID)
+	domains, err := s.client.GetCredentialDomains(ctx, folder)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	// Every store has the global domain, so a store without domains does not exist.
+	if len(domains) == 0 {
+		return nil, "", nil, nil
+	}
+
+	nr, err := credentialStoreResource(ctx, folder, parentResourceID)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return []*v2.Resource{nr}, "", nil, nil
+}
+
+// Entitlements returns one permission entitlement for each Credentials plugin permission.
+func (s *credentialStoreBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	return permissionEntitlements(resource, credentialPermissions), "", nil, nil
+}
+
+// Grants returns the effective credential permissions of users and groups on a store. The system store is governed
+// by the global roles or the global matrix. Folder stores are governed like their folder: with Role Strategy by the
+// global roles and the project roles whose pattern matches the folder full name, with project-based matrix
+// authorization by the matrix of the folder and the ones it inherits.
+func (s *credentialStoreBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	patternRoleType := client.ProjectRoles
+	folder := credentialStoreFolder(resource.Id.Resource)
+	if folder == "" {
+		patternRoleType = client.GlobalRoles
+	}
+
+	entries, err := itemEntries(ctx, s.client, s.authorizationStrategy, patternRoleType, folder)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	rv, err := permissionGrants(ctx, resource, credentialPermissions, entries)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return rv, "", nil, nil
+}
+
+func newCredentialStoreBuilder(client *client.JenkinsClient, authorizationStrategy string) *credentialStoreBuilder {
+	return &credentialStoreBuilder{
+		resourceType:          resourceTypeCredentialStore,
+		client:                client,
+		authorizationStrategy: authorizationStrategy,
+	}
+}
@@ -0,0 +1,62 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCredentialStoreID(t *testing.T) {
+	assert.Equal(t, "system", credentialStoreID(""))
+	assert.Equal(t, "", credentialStoreFolder(credentialStoreID("")))
+	assert.Equal(t, "folder:team/apps", credentialStoreID("team/apps"))
+	assert.Equal(t, "team/apps", credentialStoreFolder(credentialStoreID("team/apps")))
+}
+
+func TestCredentialProfile(t *testing.T) {
+	credential := client.Credential{ID: "deploy", TypeName: "SSH Username with private key", Description: "Deploy key"}
+	global := client.CredentialDomain{URLName: client.GlobalDomain, DisplayName: "Global credentials (unrestricted)"}
+
+	assert.Equal(t, map[string]interface{}{
+		"credential_id": "deploy",
+		"kind":          "SSH Username with private key",
+		"domain":        client.GlobalDomain,
+		"domain_name":   "Global credentials (unrestricted)",
+		"scope":         "SYSTEM",
+	}, credentialProfile(credential, "SYSTEM", global, ""))
+	assert.Equal(t, map[string]interface{}{
+		"credential_id": "deploy",
+		"kind":          "SSH Username with private key",
+		"domain":        client.GlobalDomain,
+		"domain_name":   "Global credentials (unrestricted)",
+		"folder":        "team",
+	}, credentialProfile(credential, "", global, "team"))
+
+	resource, err := credentialResource(ctx, credential, globalScope, global, &v2.ResourceId{
+		ResourceType: resourceTypeCredentialStore.Id,
+		Resource:     credentialStoreID("team"),
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Deploy key", resource.Description)
+}
+
+func TestCredentialStoreGrants(t *testing.T) {
+	store, err := credentialStoreResource(ctx, "team", nil)
+	assert.Nil(t, err)
+
+	entries := []permissionEntry{
+		{permission: "com.cloudbees.plugins.credentials.CredentialsProvider.Update", sid: client.Role{Sid: "jane", Type: client.SidTypeUser}},
+		{permission: "hudson.model.Item.Read", sid: client.Role{Sid: "bob", Type: client.SidTypeUser}},
+	}
+	grants, err := permissionGrants(ctx, store, credentialPermissions, entries)
+	assert.Nil(t, err)
+
+	var entitlementIds []string
+	for _, grant := range grants {
+		assert.Equal(t, "jane", grant.Principal.Id.Resource)
+		entitlementIds = append(entitlementIds, grant.Entitlement.Id)
+	}
+	assert.Equal(t, []string{"credential_store:folder:team:update", "credential_store:folder:team:view"}, entitlementIds)
+}
@@ -0,0 +1,162 @@
+package connector
+
+import (
+	"context"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+type folderBuilder struct {
+	resourceType          *v2.ResourceType
+	client                *client.JenkinsClient
+	authorizationStrategy string
+}
+
+// folderName returns the full name of the folder a resource ID refers to, or an empty string for the root.
+func folderName(resourceID *v2.ResourceId) string {
+	if resourceID == nil || resourceID.ResourceType != resourceTypeFolder.Id {
+		return ""
+	}
+
+	return resourceID.Resource
+}
+
+// Create a new connector resource for a Jenkins folder, organization folder or multibranch project.
+func folderResource(ctx context.Context, folder client.Job, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	profile := map[string]interface{}{
+		"node_id":   folder.FullName,
+		"node_name": folder.Name,
+		"url":       folder.URL,
+		"class":     folder.Class,
+	}
+
+	groupTraitOptions := []rs.GroupTraitOption{
+		rs.WithGroupProfile(profile),
+	}
+
+	ret, err := rs.NewGroupResource(
+		folder.FullName,
+		resourceTypeFolder,
+		folder.FullName,
+		groupTraitOptions,
+		rs.WithParentResourceID(parentResourceID),
+		rs.WithAnnotation(
+			&v2.ChildResourceType{ResourceTypeId: resourceTypeFolder.Id},
+			&v2.ChildResourceType{ResourceTypeId: resourceTypeJob.Id},
+			&v2.ChildResourceType{ResourceTypeId: resourceTypeCredentialStore.Id},
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (f *folderBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return f.resourceType
+}
+
+// List returns the top-level folders, or the sub-folders of a folder when the parent resource is a folder.
+func (f *folderBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	var rv []*v2.Resource
+	jobs, err := f.client.GetJobs(ctx, folderName(parentResourceID))
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	for _, job := range jobs {
+		if !job.IsFolder() {
+			continue
+		}
+
+		nr, err := folderResource(ctx, job, parentResourceID)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		rv = append(rv, nr)
+	}
+
+	return rv, "", nil, nil
+}
+
+// Entitlements returns one permission entitlement for each item permission. With folder-based authorization,
+// each folder role listing the folder is an entitlement too.
+func (f *folderBuilder) Entitlements(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	rv := permissionEntitlements(resource, jobPermissions)
+	if f.authorizationStrategy != folderAuthStrategy {
+		return rv, "", nil, nil
+	}
+
+	roles, err := f.client.GetFolderAuthRoles(ctx, client.FolderAuthFolderRoles)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return append(rv, folderRoleEntitlements(resource, roles)...), "", nil, nil
+}
+
+// Grants returns the effective folder permissions of users and groups. With Role Strategy they are computed from
+// the global roles and from the project roles whose pattern matches the folder full name, with Matrix
+// Authorization from the global matrix. With project-based matrix authorization the matrix of the folder and
+// the ones it inherits are used. With folder-based authorization the global roles and the folder roles of the
+// folder and of its parents are used, and the assignments of the folder roles listing the folder are returned too.
+func (f *folderBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	entries, err := itemEntries(ctx, f.client, f.authorizationStrategy, client.ProjectRoles, resource.Id.Resource)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	rv, err := permissionGrants(ctx, resource, jobPermissions, entries)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	if f.authorizationStrategy != folderAuthStrategy {
+		return rv, "", nil, nil
+	}
+
+	roles, err := f.client.GetFolderAuthRoles(ctx, client.FolderAuthFolderRoles)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	roleGrants, err := folderRoleGrants(ctx, resource, roles, knownGroups(ctx, f.client))
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return append(rv, roleGrants...), "", nil, nil
+}
+
+// Grant gives a user or group a folder permission, which is only supported with project-based matrix
+// authorization, or assigns them a folder role with folder-based authorization.
+func (f *folderBuilder) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+	if roleName, ok := folderRoleFromEntitlement(entitlement); ok && f.authorizationStrategy == folderAuthStrategy {
+		return nil, assignFolderAuthRole(ctx, f.client, client.FolderAuthFolderRoles, roleName, principal)
+	}
+
+	return nil, grantItemPermission(ctx, f.client, f.authorizationStrategy, principal, entitlement)
+}
+
+// Revoke takes a folder permission away from a user or group, which is only supported with project-based matrix
+// authorization, or removes them from a folder role with folder-based authorization.
+func (f *folderBuilder) Revoke(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
+	if roleName, ok := folderRoleFromEntitlement(grant.Entitlement); ok && f.authorizationStrategy == folderAuthStrategy {
+		return nil, unassignFolderAuthRole(ctx, f.client, client.FolderAuthFolderRoles, roleName, grant.Principal)
+	}
+
+	return nil, revokeItemPermission(ctx, f.client, f.authorizationStrategy, grant)
+}
+
+func newFolderBuilder(client *client.JenkinsClient, authorizationStrategy string) *folderBuilder {
+	return &folderBuilder{
+		resourceType:          resourceTypeFolder,
+		client:                client,
+		authorizationStrategy: authorizationStrategy,
+	}
+}
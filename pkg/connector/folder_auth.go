@@ -0,0 +1,196 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
+
+// folderRoleSlugPrefix prefixes the slug of the entitlements of folder roles, which are followed by the role name.
+// Permission slugs never start with it.
+const folderRoleSlugPrefix = "role-"
+
+// folderRoleApplies reports whether a folder role applies to an item, i.e. whether the item is one of the folders
+// of the role or lies inside one of them.
+func folderRoleApplies(role client.FolderAuthRole, fullName string) bool {
+	for _, folder := range role.Folders {
+		if fullName == folder || strings.HasPrefix(fullName, folder+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// folderAuthSid returns the sid of a member of a folder-based authorization role. The plugin does not tell users
+// and groups apart, so sids naming one of the known groups are resolved as groups.
+func folderAuthSid(sid string, groups map[string]bool) client.Role {
+	return resolveSid(client.Role{Sid: sid, Type: client.SidTypeEither}, groups)
+}
+
+// folderAuthEntries expands the Folder-based Authorization Strategy roles into permission entries. Global roles
+// always apply. With patternRoleType set to project roles, the folder roles applying to the item name are added,
+// with agent roles the agent roles listing the agent name.
+func folderAuthEntries(roles []client.FolderAuthRole, groups map[string]bool, patternRoleType, name string) []permissionEntry {
+	var entries []permissionEntry
+	for _, role := range roles {
+		switch role.Type {
+		case client.FolderAuthGlobalRoles:
+		case client.FolderAuthFolderRoles:
+			if patternRoleType != client.ProjectRoles || !folderRoleApplies(role, name) {
+				continue
+			}
+		case client.FolderAuthAgentRoles:
+			if patternRoleType != client.SlaveRoles || !slices.Contains(role.Agents, name) {
+				continue
+			}
+		default:
+			continue
+		}
+
+		for _, permission := range role.PermissionIDs() {
+			for _, sid := range role.Sids {
+				entries = append(entries, permissionEntry{
+					permission: permission,
+					sid:        folderAuthSid(sid, groups),
+				})
+			}
+		}
+	}
+
+	return entries
+}
+
+// directFolderRoles returns the folder roles listing the folder itself, which are the ones provisioned through it.
+func directFolderRoles(roles []client.FolderAuthRole, folder string) []client.FolderAuthRole {
+	var rv []client.FolderAuthRole
+	for _, role := range roles {
+		if role.Type == client.FolderAuthFolderRoles && slices.Contains(role.Folders, folder) {
+			rv = append(rv, role)
+		}
+	}
+
+	return rv
+}
+
+// folderRoleEntitlements creates one entitlement for each folder role listing the folder.
+func folderRoleEntitlements(resource *v2.Resource, roles []client.FolderAuthRole) []*v2.Entitlement {
+	var rv []*v2.Entitlement
+	for _, role := range directFolderRoles(roles, resource.Id.Resource) {
+		rv = append(rv, ent.NewPermissionEntitlement(resource, folderRoleSlugPrefix+role.Name,
+			ent.WithGrantableTo(resourceTypeUser, resourceTypeGroup),
+			ent.WithDisplayName(fmt.Sprintf("%s Folder Role %s", resource.DisplayName, role.Name)),
+			ent.WithDescription(fmt.Sprintf("%s folder role (%s) on Jenkins folders %s", role.Name,
+				strings.Join(role.PermissionIDs(), ", "), strings.Join(role.Folders, ", "))),
+		))
+	}
+
+	return rv
+}
+
+// folderRoleGrants creates a grant for every sid assigned to a folder role listing the folder.
+func folderRoleGrants(ctx context.Context, resource *v2.Resource, roles []client.FolderAuthRole, groups map[string]bool) ([]*v2.Grant, error) {
+	var rv []*v2.Grant
+	for _, role := range directFolderRoles(roles, resource.Id.Resource) {
+		for _, sid := range role.Sids {
+			principalId, err := sidResourceId(ctx, folderAuthSid(sid, groups))
+			if err != nil {
+				return nil, fmt.Errorf("error creating principal %s for folder role %s: %w", sid, role.Name, err)
+			}
+
+			rv = append(rv, sidGrant(resource, folderRoleSlugPrefix+role.Name, principalId))
+		}
+	}
+
+	return rv, nil
+}
+
+// folderRoleFromEntitlement returns the folder role an entitlement of a folder refers to, and whether it refers
+// to one at all rather than to a permission.
+func folderRoleFromEntitlement(entitlement *v2.Entitlement) (string, bool) {
+	return strings.CutPrefix(entitlementSlug(entitlement), folderRoleSlugPrefix)
+}
+
+// validateFolderAuthRole returns the role of a type with the given name.
+func validateFolderAuthRole(ctx context.Context, c *client.JenkinsClient, roleType, roleName string) (*client.FolderAuthRole, error) {
+	roles, err := c.GetFolderAuthRoles(ctx, roleType)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := slices.IndexFunc(roles, func(role client.FolderAuthRole) bool {
+		return role.Name == roleName
+	})
+	if idx == -1 {
+		return nil, fmt.Errorf("jenkins-connector: %s role %s not found", strings.ToLower(roleType), roleName)
+	}
+
+	return &roles[idx], nil
+}
+
+// assignFolderAuthRole assigns a user or group to a Folder-based Authorization Strategy role.
+func assignFolderAuthRole(ctx context.Context, c *client.JenkinsClient, roleType, roleName string, principal *v2.Resource) error {
+	l := ctxzap.Extract(ctx)
+	if _, err := principalSidType(principal); err != nil {
+		return err
+	}
+
+	role, err := validateFolderAuthRole(ctx, c, roleType, roleName)
+	if err != nil {
+		return err
+	}
+
+	sid := principal.Id.Resource
+	if slices.Contains(role.Sids, sid) {
+		return fmt.Errorf("jenkins-connector: %s %s already has the %s role %s", principal.Id.ResourceType, sid, strings.ToLower(roleType), roleName)
+	}
+
+	if _, err := c.AssignSidToFolderAuthRole(ctx, roleType, roleName, sid); err != nil {
+		return err
+	}
+
+	l.Warn("Role has been granted.",
+		zap.String("roleType", roleType),
+		zap.String("roleId", roleName),
+		zap.String("sid", sid),
+	)
+
+	return nil
+}
+
+// unassignFolderAuthRole removes a user or group from a Folder-based Authorization Strategy role.
+func unassignFolderAuthRole(ctx context.Context, c *client.JenkinsClient, roleType, roleName string, principal *v2.Resource) error {
+	l := ctxzap.Extract(ctx)
+	if _, err := principalSidType(principal); err != nil {
+		return err
+	}
+
+	role, err := validateFolderAuthRole(ctx, c, roleType, roleName)
+	if err != nil {
+		return err
+	}
+
+	sid := principal.Id.Resource
+	if !slices.Contains(role.Sids, sid) {
+		return fmt.Errorf("jenkins-connector: %s %s does not have the %s role %s", principal.Id.ResourceType, sid, strings.ToLower(roleType), roleName)
+	}
+
+	if _, err := c.RemoveSidFromFolderAuthRole(ctx, roleType, roleName, sid); err != nil {
+		return err
+	}
+
+	l.Warn("Role has been revoked.",
+		zap.String("roleType", roleType),
+		zap.String("roleId", roleName),
+		zap.String("sid", sid),
+	)
+
+	return nil
+}
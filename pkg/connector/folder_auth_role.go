@@ -0,0 +1,185 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+)
+
+// folderAuthRoleBuilder syncs the global and agent roles of the Folder-based Authorization Strategy plugin.
+// Folder roles are synced as entitlements of the folders they list.
+type folderAuthRoleBuilder struct {
+	resourceType *v2.ResourceType
+	client       *client.JenkinsClient
+}
+
+// folderAuthRoleTypeNames maps the Folder-based Authorization Strategy role types synced as roles to the short
+// names used in role resource IDs.
+var folderAuthRoleTypeNames = map[string]string{
+	client.FolderAuthGlobalRoles: "global",
+	client.FolderAuthAgentRoles:  "agent",
+}
+
+// folderAuthRoleResourceID returns the resource ID of a global or agent role.
+func folderAuthRoleResourceID(roleType, roleName string) string {
+	return fmt.Sprintf("%s:%s", folderAuthRoleTypeNames[roleType], roleName)
+}
+
+// parseFolderAuthRoleResourceID splits a role resource ID into its role type and role name.
+func parseFolderAuthRoleResourceID(id string) (string, string, error) {
+	typeName, roleName, ok := strings.Cut(id, ":")
+	if !ok || roleName == "" {
+		return "", "", fmt.Errorf("jenkins-connector: invalid role id %s", id)
+	}
+
+	for roleType, name := range folderAuthRoleTypeNames {
+		if name == typeName {
+			return roleType, roleName, nil
+		}
+	}
+
+	return "", "", fmt.Errorf("jenkins-connector: invalid role type %s in role id %s", typeName, id)
+}
+
+// Create a new connector resource for a global or agent role of the Folder-based Authorization Strategy plugin.
+func folderAuthRoleResource(ctx context.Context, role client.FolderAuthRole, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	typeName := folderAuthRoleTypeNames[role.Type]
+	profile := map[string]interface{}{
+		"node_id":     role.Name,
+		"node_name":   role.Name,
+		"role_type":   typeName,
+		"permissions": strings.Join(role.PermissionIDs(), ","),
+	}
+	if len(role.Agents) > 0 {
+		profile["agents"] = strings.Join(role.Agents, ",")
+	}
+
+	ret, err := rs.NewGroupResource(
+		fmt.Sprintf("%s (%s)", role.Name, typeName),
+		resourceTypeRole,
+		folderAuthRoleResourceID(role.Type, role.Name),
+		[]rs.GroupTraitOption{rs.WithGroupProfile(profile)},
+		rs.WithParentResourceID(parentResourceID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (r *folderAuthRoleBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return r.resourceType
+}
+
+// roles returns the global and agent roles.
+func (r *folderAuthRoleBuilder) roles(ctx context.Context) ([]client.FolderAuthRole, error) {
+	var rv []client.FolderAuthRole
+	for _, roleType := range []string{client.FolderAuthGlobalRoles, client.FolderAuthAgentRoles} {
+		roles, err := r.client.GetFolderAuthRoles(ctx, roleType)
+		if err != nil {
+			return nil, err
+		}
+
+		rv = append(rv, roles...)
+	}
+
+	return rv, nil
+}
+
+// List returns the global and agent roles as resource objects.
+func (r *folderAuthRoleBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	var rv []*v2.Resource
+	roles, err := r.roles(ctx)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	for _, role := range roles {
+		nr, err := folderAuthRoleResource(ctx, role, parentResourceID)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		rv = append(rv, nr)
+	}
+
+	return rv, "", nil, nil
+}
+
+// Entitlements returns the assignment entitlement of a role, named after the role.
+func (r *folderAuthRoleBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	roleType, roleName, err := parseFolderAuthRoleResourceID(resource.Id.Resource)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	rv := []*v2.Entitlement{
+		ent.NewPermissionEntitlement(resource, roleName,
+			ent.WithGrantableTo(resourceTypeUser, resourceTypeGroup),
+			ent.WithDisplayName(fmt.Sprintf("%s Role %s", resource.DisplayName, roleName)),
+			ent.WithDescription(fmt.Sprintf("%s access to %s - %s %s role in Jenkins", titleCase(roleName), resource.Id.Resource, roleName, folderAuthRoleTypeNames[roleType])),
+		),
+	}
+
+	return rv, "", nil, nil
+}
+
+// Grants returns a grant for every sid assigned to the role.
+func (r *folderAuthRoleBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	var rv []*v2.Grant
+	roleType, roleName, err := parseFolderAuthRoleResourceID(resource.Id.Resource)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	role, err := validateFolderAuthRole(ctx, r.client, roleType, roleName)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	groups := knownGroups(ctx, r.client)
+	for _, sid := range role.Sids {
+		principalId, err := sidResourceId(ctx, folderAuthSid(sid, groups))
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("error creating principal %s for role %s: %w", sid, resource.Id.Resource, err)
+		}
+
+		rv = append(rv, sidGrant(resource, roleName, principalId))
+	}
+
+	return rv, "", nil, nil
+}
+
+// Grant assigns a user or group to the role.
+func (r *folderAuthRoleBuilder) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+	roleType, roleName, err := parseFolderAuthRoleResourceID(entitlement.Resource.Id.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, assignFolderAuthRole(ctx, r.client, roleType, roleName, principal)
+}
+
+// Revoke removes a user or group from the role.
+func (r *folderAuthRoleBuilder) Revoke(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
+	roleType, roleName, err := parseFolderAuthRoleResourceID(grant.Entitlement.Resource.Id.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, unassignFolderAuthRole(ctx, r.client, roleType, roleName, grant.Principal)
+}
+
+func newFolderAuthRoleBuilder(client *client.JenkinsClient) *folderAuthRoleBuilder {
+	return &folderAuthRoleBuilder{
+		resourceType: resourceTypeRole,
+		client:       client,
+	}
+}
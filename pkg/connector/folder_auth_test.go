@@ -0,0 +1,65 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFolderAuthEntries(t *testing.T) {
+	roles := []client.FolderAuthRole{
+		{Type: client.FolderAuthGlobalRoles, Name: "readers", Permissions: []client.FolderAuthPermission{"hudson.model.Hudson.Read"}, Sids: []string{"authenticated"}},
+		{Type: client.FolderAuthFolderRoles, Name: "deployers", Permissions: []client.FolderAuthPermission{"hudson.model.Item.Build"}, Sids: []string{"jane"}, Folders: []string{"team"}},
+		{Type: client.FolderAuthAgentRoles, Name: "operators", Permissions: []client.FolderAuthPermission{"hudson.model.Computer.Connect"}, Sids: []string{"bob"}, Agents: []string{"agent-1"}},
+	}
+
+	assert.Len(t, folderAuthEntries(roles, nil, client.ProjectRoles, "team"), 2)
+	assert.Len(t, folderAuthEntries(roles, nil, client.ProjectRoles, "team/app"), 2)
+	assert.Len(t, folderAuthEntries(roles, nil, client.ProjectRoles, "team-b"), 1)
+	assert.Len(t, folderAuthEntries(roles, nil, client.SlaveRoles, "agent-1"), 2)
+	assert.Len(t, folderAuthEntries(roles, nil, client.GlobalRoles, ""), 1)
+
+	folder, err := folderResource(ctx, client.Job{Name: "team", FullName: "team"}, nil)
+	assert.Nil(t, err)
+	entitlements := folderRoleEntitlements(folder, roles)
+	assert.Len(t, entitlements, 1)
+	roleName, ok := folderRoleFromEntitlement(entitlements[0])
+	assert.True(t, ok)
+	assert.Equal(t, "deployers", roleName)
+
+	_, ok = folderRoleFromEntitlement(permissionEntitlements(folder, jobPermissions)[0])
+	assert.False(t, ok)
+}
+
+func TestFolderAuthGroupSids(t *testing.T) {
+	roles := []client.FolderAuthRole{
+		{Type: client.FolderAuthFolderRoles, Name: "deployers", Permissions: []client.FolderAuthPermission{"hudson.model.Item.Build"}, Sids: []string{"jane", "developers"}, Folders: []string{"team"}},
+	}
+	groups := map[string]bool{"developers": true}
+
+	entries := folderAuthEntries(roles, groups, client.ProjectRoles, "team")
+	assert.Equal(t, []client.Role{
+		{Sid: "jane", Type: client.SidTypeEither},
+		{Sid: "developers", Type: client.SidTypeGroup},
+	}, []client.Role{entries[0].sid, entries[1].sid})
+
+	folder, err := folderResource(ctx, client.Job{Name: "team", FullName: "team"}, nil)
+	assert.Nil(t, err)
+	grants, err := folderRoleGrants(ctx, folder, roles, groups)
+	assert.Nil(t, err)
+	assert.Len(t, grants, 2)
+	assert.Equal(t, resourceTypeUser.Id, grants[0].Principal.Id.ResourceType)
+	assert.Equal(t, resourceTypeGroup.Id, grants[1].Principal.Id.ResourceType)
+	assert.Equal(t, "developers", grants[1].Principal.Id.Resource)
+}
+
+func TestFolderAuthRoleResourceID(t *testing.T) {
+	roleType, roleName, err := parseFolderAuthRoleResourceID(folderAuthRoleResourceID(client.FolderAuthAgentRoles, "operators"))
+	assert.Nil(t, err)
+	assert.Equal(t, client.FolderAuthAgentRoles, roleType)
+	assert.Equal(t, "operators", roleName)
+
+	_, _, err = parseFolderAuthRoleResourceID("folder:deployers")
+	assert.NotNil(t, err)
+}
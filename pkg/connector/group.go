@@ -3,28 +3,26 @@ package connector
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strconv"
 
 	"github.com/conductorone/baton-jenkins/pkg/client"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/annotations"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
 	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	gr "github.com/conductorone/baton-sdk/pkg/types/grant"
 	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
 )
 
-const (
-	memberEntitlement = "member"
-	adminEntitlement  = "admin"
-)
+const memberEntitlement = "member"
 
 type groupBuilder struct {
-	resourceType *v2.ResourceType
-	client       *client.JenkinsClient
-}
-
-var groupEntitlementAccessLevels = []string{
-	memberEntitlement,
-	adminEntitlement,
+	resourceType          *v2.ResourceType
+	client                *client.JenkinsClient
+	authorizationStrategy string
+	securityRealm         string
+	usersSource           string
 }
 
 // groupResource gets a new connector resource for a Jenkins group.
@@ -56,22 +54,26 @@ func (g *groupBuilder) ResourceType(_ context.Context) *v2.ResourceType {
 	return g.resourceType
 }
 
-// List returns all the groups from the database as resource objects.
+// List returns all the groups from the database as resource objects, along with the built-in authenticated group.
 // Groups include a GroupTrait because they are the 'shape' of a standard group.
 func (g *groupBuilder) List(ctx context.Context, parentId *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
 	var ret []*v2.Resource
-	defaultGroup := client.Group{
-		Description: "Default group",
-		FullName:    "Authenticated Users",
-		ID:          "authenticated",
+	groups, err := g.getGroups(ctx)
+	if err != nil {
+		return nil, "", nil, err
 	}
-	groups, err := g.client.GetGroups(ctx)
+
+	ar, err := authenticatedGroupResource(parentId)
 	if err != nil {
 		return nil, "", nil, err
 	}
+	ret = append(ret, ar)
 
-	groups = append(groups, defaultGroup)
 	for _, group := range groups {
+		if isBuiltInSid(group.ID) {
+			continue
+		}
+
 		res, err := groupResource(ctx, group, parentId)
 		if err != nil {
 			return nil, "", nil, err
@@ -83,38 +85,162 @@ func (g *groupBuilder) List(ctx context.Context, parentId *v2.ResourceId, pToken
 	return ret, "", nil, nil
 }
 
+// hasMemberships reports whether group memberships are known, which is the case when users authenticate through
+// an external security realm, like LDAP or Active Directory, and the script console is available.
+func (g *groupBuilder) hasMemberships() bool {
+	return g.securityRealm != "" && g.securityRealm != localRealm
+}
+
+// getGroups returns the groups referenced by the authorization strategy, along with the groups the security
+// realm granted to users. Folder-based authorization does not tell groups apart from users, so only the groups
+// of the security realm are known with it.
+func (g *groupBuilder) getGroups(ctx context.Context) ([]client.Group, error) {
+	var (
+		groups []client.Group
+		err    error
+	)
+	switch {
+	case isMatrixStrategy(g.authorizationStrategy):
+		groups, err = g.getMatrixGroups(ctx)
+	case g.authorizationStrategy == folderAuthStrategy:
+	default:
+		groups, err = g.client.GetGroups(ctx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if !g.hasMemberships() {
+		return groups, nil
+	}
+
+	realmGroups, err := g.client.GetAuthorityGroups(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, group := range realmGroups {
+		if !slices.ContainsFunc(groups, func(existing client.Group) bool {
+			return existing.ID == group.ID
+		}) {
+			groups = append(groups, group)
+		}
+	}
+
+	return groups, nil
+}
+
+// getMatrixGroups returns the groups granted a permission in the global matrix.
+func (g *groupBuilder) getMatrixGroups(ctx context.Context) ([]client.Group, error) {
+	matrix, err := g.client.GetGlobalMatrix(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var groups []client.Group
+	seen := make(map[string]bool)
+	for _, entry := range matrix.Entries {
+		if entry.Type != client.SidTypeGroup || seen[entry.Sid] {
+			continue
+		}
+
+		seen[entry.Sid] = true
+		groups = append(groups, client.Group{
+			ID: entry.Sid,
+		})
+	}
+
+	return groups, nil
+}
+
+// Entitlements returns the member entitlement of a group. Jenkins only reads group membership from the security
+// realm, so the entitlement is synced but cannot be provisioned.
 func (g *groupBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
-	var rv []*v2.Entitlement
-	for _, level := range groupEntitlementAccessLevels {
-		rv = append(rv, ent.NewPermissionEntitlement(resource, level,
-			ent.WithDisplayName(fmt.Sprintf("%s Group %s", resource.DisplayName, titleCase(level))),
-			ent.WithDescription(fmt.Sprintf("Access to %s group in Jenkins", resource.DisplayName)),
+	rv := []*v2.Entitlement{
+		ent.NewAssignmentEntitlement(resource, memberEntitlement,
+			ent.WithDisplayName(fmt.Sprintf("%s Group %s", resource.DisplayName, titleCase(memberEntitlement))),
+			ent.WithDescription(fmt.Sprintf("Member of %s group in Jenkins", resource.DisplayName)),
 			ent.WithAnnotation(&v2.V1Identifier{
-				Id: fmt.Sprintf("group:%s:role:%s", resource.Id.Resource, level),
+				Id: fmt.Sprintf("group:%s:role:%s", resource.Id.Resource, memberEntitlement),
 			}),
-			ent.WithGrantableTo(resourceTypeGroup),
-		))
+			ent.WithGrantableTo(resourceTypeUser),
+		),
 	}
 
 	return rv, "", nil, nil
 }
 
-func (g *groupBuilder) Grants(ctx context.Context, resource *v2.Resource, token *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+// Grants returns a member grant for every user the security realm granted the group to when they last logged in.
+// Every user able to log in is a member of the authenticated group.
+func (g *groupBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
 	var rv []*v2.Grant
+	if resource.Id.Resource == authenticatedGroup {
+		return g.authenticatedGrants(ctx, resource, pToken)
+	}
+
+	if !g.hasMemberships() {
+		return rv, "", nil, nil
+	}
+
+	members, err := g.client.GetGroupMembers(ctx, resource.Id.Resource)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	for _, member := range members {
+		ur, err := userResource(ctx, client.Users{User: client.User{ID: member}}, nil)
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("error creating user resource for group %s: %w", resource.Id.Resource, err)
+		}
+
+		rv = append(rv, gr.NewGrant(resource, memberEntitlement, ur.Id))
+	}
+
 	return rv, "", nil, nil
 }
 
-func (g *groupBuilder) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
-	return nil, nil
-}
+// authenticatedGrants returns a page of member grants of the authenticated group, one for every user able to log in.
+func (g *groupBuilder) authenticatedGrants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	var (
+		rv        []*v2.Grant
+		nextToken string
+	)
+	start, pageSize, err := parsePageToken(pToken)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	users, err := getUsers(ctx, g.client, g.usersSource, start, start+pageSize)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	if len(users) == pageSize {
+		nextToken = strconv.Itoa(start + pageSize)
+	}
+
+	for _, user := range users {
+		if !isAuthenticatedMember(user.User) {
+			continue
+		}
+
+		ur, err := userResource(ctx, user, nil)
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("error creating user resource for group %s: %w", resource.Id.Resource, err)
+		}
+
+		rv = append(rv, gr.NewGrant(resource, memberEntitlement, ur.Id))
+	}
 
-func (g *groupBuilder) Revoke(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
-	return nil, nil
+	return rv, nextToken, nil, nil
 }
 
-func newGroupBuilder(client *client.JenkinsClient) *groupBuilder {
+func newGroupBuilder(client *client.JenkinsClient, authorizationStrategy, securityRealm, usersSource string) *groupBuilder {
 	return &groupBuilder{
-		resourceType: resourceTypeGroup,
-		client:       client,
+		resourceType:          resourceTypeGroup,
+		client:                client,
+		authorizationStrategy: authorizationStrategy,
+		securityRealm:         securityRealm,
+		usersSource:           usersSource,
 	}
 }
@@ -2,15 +2,21 @@ package connector
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/conductorone/baton-jenkins/pkg/client"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
 	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
 
+// defaultPageSize is the number of resources listed per page when the sync does not ask for a page size.
+const defaultPageSize = 100
+
 const (
 	MembershipEntitlementIDTemplate = "membership:%s"
 	V1GrantIDTemplate               = "grant:%s:%s"
@@ -41,7 +47,7 @@ func titleCase(s string) string {
 func ParseEntitlementID(id string) (*v2.ResourceId, []string, error) {
 	parts := strings.Split(id, ":")
 	// Need to be at least 3 parts type:entitlement_id:slug
-	if len(parts) < 3 || len(parts) > 3 {
+	if len(parts) < 3 {
 		return nil, nil, fmt.Errorf("jenkins-connector: invalid resource id")
 	}
 
@@ -56,7 +62,7 @@ func ParseEntitlementID(id string) (*v2.ResourceId, []string, error) {
 func ParseGrantID(id string) (*v2.ResourceId, []string, error) {
 	parts := strings.Split(id, ":")
 	// Need to be at least 5 parts type:grant_id:slug:resource_id:resource_type
-	if len(parts) < 5 || len(parts) > 5 {
+	if len(parts) < 5 {
 		return nil, nil, fmt.Errorf("jenkins-connector: invalid resource id")
 	}
 
@@ -75,3 +81,44 @@ func V1MembershipEntitlementID(resourceID string) string {
 func V1GrantID(entitlementID string, userID string) string {
 	return fmt.Sprintf(V1GrantIDTemplate, entitlementID, userID)
 }
+
+// entitlementSlug returns the slug of an entitlement, i.e. its ID without the resource type and resource ID prefix.
+func entitlementSlug(entitlement *v2.Entitlement) string {
+	prefix := fmt.Sprintf("%s:%s:", entitlement.Resource.Id.ResourceType, entitlement.Resource.Id.Resource)
+	return strings.TrimPrefix(entitlement.Id, prefix)
+}
+
+// principalSidType returns the Jenkins sid type of a user or group principal.
+func principalSidType(principal *v2.Resource) (string, error) {
+	switch principal.Id.ResourceType {
+	case resourceTypeUser.Id:
+		return client.SidTypeUser, nil
+	case resourceTypeGroup.Id:
+		return client.SidTypeGroup, nil
+	default:
+		return "", fmt.Errorf("jenkins-connector: invalid grant resource type: %s", principal.Id.ResourceType)
+	}
+}
+
+// parsePageToken returns the offset and the page size of a page. The token holds the offset of the page.
+func parsePageToken(pToken *pagination.Token) (int, int, error) {
+	pageSize := defaultPageSize
+	if pToken == nil {
+		return 0, pageSize, nil
+	}
+
+	if pToken.Size > 0 {
+		pageSize = pToken.Size
+	}
+
+	if pToken.Token == "" {
+		return 0, pageSize, nil
+	}
+
+	start, err := strconv.Atoi(pToken.Token)
+	if err != nil || start < 0 {
+		return 0, 0, fmt.Errorf("jenkins-connector: invalid page token %q", pToken.Token)
+	}
+
+	return start, pageSize, nil
+}
@@ -0,0 +1,23 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePageToken(t *testing.T) {
+	start, size, err := parsePageToken(&pagination.Token{})
+	assert.Nil(t, err)
+	assert.Equal(t, 0, start)
+	assert.Equal(t, defaultPageSize, size)
+
+	start, size, err = parsePageToken(&pagination.Token{Size: 50, Token: "150"})
+	assert.Nil(t, err)
+	assert.Equal(t, 150, start)
+	assert.Equal(t, 50, size)
+
+	_, _, err = parsePageToken(&pagination.Token{Token: "next"})
+	assert.NotNil(t, err)
+}
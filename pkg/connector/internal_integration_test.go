@@ -30,19 +30,19 @@ func TestResourceTypeGrantFails(t *testing.T) {
 		t.Skip()
 	}
 
-	grantEntitlement := "role:reviewer:reviewer"
+	grantEntitlement := "role:global:reviewer:reviewer"
 	grantPrincipal := "localuser"
 	grantPrincipalType := "user"
 	_, data, err := ParseEntitlementID(grantEntitlement)
 	assert.Nil(t, err)
 	assert.NotNil(t, data)
-	roleId = data[1]
-	roleEntitlement = data[2]
+	roleId = data[2]
+	roleEntitlement = data[3]
 	userId = grantPrincipal
 	users := getUserForTesting(userId, userId)
 	principal, err := userResource(ctx, *users, nil)
 	assert.Nil(t, err)
-	resource, err := roleResource(ctx, roleId, nil)
+	resource, err := roleResource(ctx, getRoleForTesting(roleId), nil)
 	assert.Nil(t, err)
 	entitlement := getEntitlementForTesting(resource, grantPrincipalType, roleEntitlement)
 	cli := getJenkinsClientForTesting()
@@ -59,19 +59,19 @@ func TestResourceTypeGrant(t *testing.T) {
 		t.Skip()
 	}
 
-	grantEntitlement := "role:reviewer:reviewer"
+	grantEntitlement := "role:global:reviewer:reviewer"
 	grantPrincipal := "localuser"
 	grantPrincipalType := "user"
 	_, data, err := ParseEntitlementID(grantEntitlement)
 	assert.Nil(t, err)
 	assert.NotNil(t, data)
-	roleId = data[1]
-	roleEntitlement = data[2]
+	roleId = data[2]
+	roleEntitlement = data[3]
 	userId = grantPrincipal
 	users := getUserForTesting(userId, userId)
 	principal, err := userResource(ctx, *users, nil)
 	assert.Nil(t, err)
-	resource, err := roleResource(ctx, roleId, nil)
+	resource, err := roleResource(ctx, getRoleForTesting(roleId), nil)
 	assert.Nil(t, err)
 	entitlement := getEntitlementForTesting(resource, grantPrincipalType, roleEntitlement)
 	cli := getJenkinsClientForTesting()
@@ -81,27 +81,27 @@ func TestResourceTypeGrant(t *testing.T) {
 }
 
 func TestResourceTypeRevokeFails(t *testing.T) {
-	// --revoke-grant "role:reviewer:reviewer:user:localuser"
+	// --revoke-grant "role:global:reviewer:reviewer:user:localuser"
 	var roleId, userId string
 	if userName == "" && (password == "" || token == "") {
 		t.Skip()
 	}
 
-	revokeGrant := "role:reviewer:reviewer:user:localuser"
+	revokeGrant := "role:global:reviewer:reviewer:user:localuser"
 	_, roleData, err := ParseGrantID(revokeGrant)
 	assert.Nil(t, err)
 	assert.NotNil(t, roleData)
-	grantEntitlement := fmt.Sprintf("%s:%s:%s", roleData[0], roleData[1], roleData[2])
-	grantPrincipal := roleData[4]
+	grantEntitlement := fmt.Sprintf("%s:%s:%s:%s", roleData[0], roleData[1], roleData[2], roleData[3])
+	grantPrincipal := roleData[5]
 	_, data, err := ParseEntitlementID(grantEntitlement)
 	assert.Nil(t, err)
 	assert.NotNil(t, data)
-	roleId = data[1]
+	roleId = data[2]
 	userId = grantPrincipal
 	users := getUserForTesting(userId, userId)
 	principal, err := userResource(ctx, *users, nil)
 	assert.Nil(t, err)
-	resource, err := roleResource(ctx, roleId, nil)
+	resource, err := roleResource(ctx, getRoleForTesting(roleId), nil)
 	assert.Nil(t, err)
 	cli := getJenkinsClientForTesting()
 	roleBuilder := getRoleBuilderForTesting(cli)
@@ -119,27 +119,27 @@ func TestResourceTypeRevokeFails(t *testing.T) {
 }
 
 func TestResourceTypeRevoke(t *testing.T) {
-	// --revoke-grant "role:reviewer:reviewer:user:localuser"
+	// --revoke-grant "role:global:reviewer:reviewer:user:localuser"
 	var roleId, userId string
 	if userName == "" && (password == "" || token == "") {
 		t.Skip()
 	}
 
-	revokeGrant := "role:reviewer:reviewer:user:localuser"
+	revokeGrant := "role:global:reviewer:reviewer:user:localuser"
 	_, roleData, err := ParseGrantID(revokeGrant)
 	assert.Nil(t, err)
 	assert.NotNil(t, roleData)
-	grantEntitlement := fmt.Sprintf("%s:%s:%s", roleData[0], roleData[1], roleData[2])
-	grantPrincipal := roleData[4]
+	grantEntitlement := fmt.Sprintf("%s:%s:%s:%s", roleData[0], roleData[1], roleData[2], roleData[3])
+	grantPrincipal := roleData[5]
 	_, data, err := ParseEntitlementID(grantEntitlement)
 	assert.Nil(t, err)
 	assert.NotNil(t, data)
-	roleId = data[1]
+	roleId = data[2]
 	userId = grantPrincipal
 	users := getUserForTesting(userId, userId)
 	principal, err := userResource(ctx, *users, nil)
 	assert.Nil(t, err)
-	resource, err := roleResource(ctx, roleId, nil)
+	resource, err := roleResource(ctx, getRoleForTesting(roleId), nil)
 	assert.Nil(t, err)
 	cli := getJenkinsClientForTesting()
 	roleBuilder := getRoleBuilderForTesting(cli)
@@ -161,6 +161,13 @@ func getRoleBuilderForTesting(client *client.JenkinsClient) *roleBuilder {
 	}
 }
 
+func getRoleForTesting(roleName string) client.RolesAPIData {
+	return client.RolesAPIData{
+		RoleName: roleName,
+		RoleType: client.GlobalRoles,
+	}
+}
+
 func getUserForTesting(userId, fullName string) *client.Users {
 	return &client.Users{
 		User: client.User{
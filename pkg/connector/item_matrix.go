@@ -0,0 +1,182 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
+
+// parentItem returns the full name of the folder containing an item, or an empty string for top-level items.
+func parentItem(fullName string) string {
+	idx := strings.LastIndex(fullName, "/")
+	if idx == -1 {
+		return ""
+	}
+
+	return fullName[:idx]
+}
+
+// allItems returns every job and folder of a folder, or of the whole controller when folder is empty.
+func allItems(ctx context.Context, c *client.JenkinsClient, folder string) ([]client.Job, error) {
+	jobs, err := c.GetJobs(ctx, folder)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []client.Job
+	for _, job := range jobs {
+		items = append(items, job)
+		if !job.IsFolder() {
+			continue
+		}
+
+		children, err := allItems(ctx, c, job.FullName)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, children...)
+	}
+
+	return items, nil
+}
+
+// projectMatrixEntries returns the effective matrix entries of a job or folder. The matrix of the item is combined
+// with the matrices of its parent folders and with the global matrix, as allowed by each inheritance strategy.
+func projectMatrixEntries(ctx context.Context, c *client.JenkinsClient, fullName string) ([]permissionEntry, error) {
+	var entries []permissionEntry
+	global, err := c.GetGlobalMatrix(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Overall/Administer cannot be taken away by an inheritance strategy.
+	for _, entry := range matrixEntries(global.Entries) {
+		if entry.permission == administerPermission {
+			entries = append(entries, entry)
+		}
+	}
+
+	for name := fullName; name != ""; name = parentItem(name) {
+		matrix, err := c.GetItemMatrix(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+
+		if matrix == nil {
+			continue
+		}
+
+		entries = append(entries, matrixEntries(matrix.Entries)...)
+		switch matrix.InheritanceStrategy {
+		case client.NonInheritingStrategy:
+			return entries, nil
+		case client.InheritGlobalStrategy:
+			return append(entries, matrixEntries(global.Entries)...), nil
+		}
+	}
+
+	return append(entries, matrixEntries(global.Entries)...), nil
+}
+
+// itemMatrixEntry returns the matrix entry a grant of a job or folder permission entitlement corresponds to.
+func itemMatrixEntry(principal *v2.Resource, entitlement *v2.Entitlement) (client.MatrixEntry, error) {
+	sidType, err := principalSidType(principal)
+	if err != nil {
+		return client.MatrixEntry{}, err
+	}
+
+	slug := entitlementSlug(entitlement)
+	idx := slices.IndexFunc(jobPermissions, func(p jenkinsPermission) bool {
+		return p.slug == slug
+	})
+	if idx == -1 {
+		return client.MatrixEntry{}, fmt.Errorf("jenkins-connector: unknown item permission %s", slug)
+	}
+
+	return client.MatrixEntry{
+		Permission: jobPermissions[idx].id,
+		Sid:        principal.Id.Resource,
+		Type:       sidType,
+	}, nil
+}
+
+// grantItemPermission grants a job or folder permission by adding an entry to the matrix of the item.
+func grantItemPermission(ctx context.Context, c *client.JenkinsClient, strategy string, principal *v2.Resource, entitlement *v2.Entitlement) error {
+	l := ctxzap.Extract(ctx)
+	if strategy != projectMatrixStrategy {
+		return fmt.Errorf("jenkins-connector: %s permissions can only be provisioned with project-based matrix authorization", entitlement.Resource.Id.ResourceType)
+	}
+
+	entry, err := itemMatrixEntry(principal, entitlement)
+	if err != nil {
+		return err
+	}
+
+	fullName := entitlement.Resource.Id.Resource
+	matrix, err := c.GetItemMatrix(ctx, fullName)
+	if err != nil {
+		return err
+	}
+
+	if matrix != nil && hasMatrixEntry(&client.MatrixAPIData{Entries: matrix.Entries}, entry) {
+		return fmt.Errorf("jenkins-connector: %s %s already has the %s permission on %s", principal.Id.ResourceType, entry.Sid, entry.Permission, fullName)
+	}
+
+	err = c.AddItemMatrixEntry(ctx, fullName, entitlement.Resource.Id.ResourceType == resourceTypeFolder.Id, entry)
+	if err != nil {
+		return err
+	}
+
+	l.Warn("Item permission has been granted.",
+		zap.String("item", fullName),
+		zap.String("sid", entry.Sid),
+		zap.String("permission", entry.Permission),
+	)
+
+	return nil
+}
+
+// revokeItemPermission revokes a job or folder permission by removing the entry from the matrix of the item.
+// Permissions inherited from a parent folder or from the global matrix cannot be revoked on the item.
+func revokeItemPermission(ctx context.Context, c *client.JenkinsClient, strategy string, grant *v2.Grant) error {
+	l := ctxzap.Extract(ctx)
+	entitlement := grant.Entitlement
+	if strategy != projectMatrixStrategy {
+		return fmt.Errorf("jenkins-connector: %s permissions can only be provisioned with project-based matrix authorization", entitlement.Resource.Id.ResourceType)
+	}
+
+	entry, err := itemMatrixEntry(grant.Principal, entitlement)
+	if err != nil {
+		return err
+	}
+
+	fullName := entitlement.Resource.Id.Resource
+	matrix, err := c.GetItemMatrix(ctx, fullName)
+	if err != nil {
+		return err
+	}
+
+	if matrix == nil || !hasMatrixEntry(&client.MatrixAPIData{Entries: matrix.Entries}, entry) {
+		return fmt.Errorf("jenkins-connector: the %s permission of %s %s is not granted on %s, it may be inherited", entry.Permission, grant.Principal.Id.ResourceType, entry.Sid, fullName)
+	}
+
+	err = c.RemoveItemMatrixEntry(ctx, fullName, entry)
+	if err != nil {
+		return err
+	}
+
+	l.Warn("Item permission has been revoked.",
+		zap.String("item", fullName),
+		zap.String("sid", entry.Sid),
+		zap.String("permission", entry.Permission),
+	)
+
+	return nil
+}
@@ -11,15 +11,17 @@ import (
 )
 
 type jobBuilder struct {
-	resourceType *v2.ResourceType
-	client       *client.JenkinsClient
+	resourceType          *v2.ResourceType
+	client                *client.JenkinsClient
+	authorizationStrategy string
 }
 
 // Create a new connector resource for a 1Password group.
 func jobResource(ctx context.Context, job client.Job, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
 	profile := map[string]interface{}{
-		"node_id":   job.Name,
+		"node_id":   job.FullName,
 		"node_name": job.Name,
+		"url":       job.URL,
 	}
 
 	groupTraitOptions := []rs.GroupTraitOption{
@@ -27,9 +29,9 @@ func jobResource(ctx context.Context, job client.Job, parentResourceID *v2.Resou
 	}
 
 	ret, err := rs.NewGroupResource(
-		job.Name,
+		job.FullName,
 		resourceTypeJob,
-		job.Name,
+		job.FullName,
 		groupTraitOptions,
 		rs.WithParentResourceID(parentResourceID),
 	)
@@ -44,16 +46,20 @@ func (j *jobBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
 	return j.resourceType
 }
 
-// List returns all the users from the database as resource objects.
-// Users include a UserTrait because they are the 'shape' of a standard user.
+// List returns the top-level jobs, or the jobs of a folder when the parent resource is a folder.
+// Jobs are identified by their full name.
 func (j *jobBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
 	var rv []*v2.Resource
-	jobs, err := j.client.GetJobs(ctx)
+	jobs, err := j.client.GetJobs(ctx, folderName(parentResourceID))
 	if err != nil {
 		return nil, "", nil, err
 	}
 
 	for _, job := range jobs {
+		if job.IsFolder() {
+			continue
+		}
+
 		nr, err := jobResource(ctx, job, parentResourceID)
 		if err != nil {
 			return nil, "", nil, err
@@ -64,19 +70,43 @@ func (j *jobBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId,
 	return rv, "", nil, nil
 }
 
-// Entitlements always returns an empty slice for users.
+// Entitlements returns one permission entitlement for each job permission.
 func (j *jobBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
-	return nil, "", nil, nil
+	return permissionEntitlements(resource, jobPermissions), "", nil, nil
 }
 
-// Grants always returns an empty slice for users since they don't have any entitlements.
+// Grants returns the effective job permissions of users and groups. With Role Strategy they are computed from
+// the global roles and from the project roles whose pattern matches the job name, with Matrix Authorization
+// from the global matrix. With project-based matrix authorization the matrix of the item and the ones it
+// inherits are used.
 func (j *jobBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
-	return nil, "", nil, nil
+	entries, err := itemEntries(ctx, j.client, j.authorizationStrategy, client.ProjectRoles, resource.Id.Resource)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	rv, err := permissionGrants(ctx, resource, jobPermissions, entries)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return rv, "", nil, nil
+}
+
+// Grant gives a user or group a job permission. It is only supported with project-based matrix authorization.
+func (j *jobBuilder) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+	return nil, grantItemPermission(ctx, j.client, j.authorizationStrategy, principal, entitlement)
+}
+
+// Revoke takes a job permission away from a user or group. It is only supported with project-based matrix authorization.
+func (j *jobBuilder) Revoke(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
+	return nil, revokeItemPermission(ctx, j.client, j.authorizationStrategy, grant)
 }
 
-func newJobBuilder(client *client.JenkinsClient) *jobBuilder {
+func newJobBuilder(client *client.JenkinsClient, authorizationStrategy string) *jobBuilder {
 	return &jobBuilder{
-		resourceType: resourceTypeJob,
-		client:       client,
+		resourceType:          resourceTypeJob,
+		client:                client,
+		authorizationStrategy: authorizationStrategy,
 	}
 }
@@ -0,0 +1,195 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
+
+const globalMatrixId = "global"
+
+type matrixBuilder struct {
+	resourceType *v2.ResourceType
+	client       *client.JenkinsClient
+}
+
+// Create a new connector resource for the global matrix of the Matrix Authorization Strategy.
+func matrixResource(ctx context.Context, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	profile := map[string]interface{}{
+		"matrix_id":   globalMatrixId,
+		"matrix_name": "Global Matrix",
+	}
+
+	groupTraitOptions := []rs.GroupTraitOption{
+		rs.WithGroupProfile(profile),
+	}
+
+	ret, err := rs.NewGroupResource(
+		"Global Matrix",
+		resourceTypeMatrix,
+		globalMatrixId,
+		groupTraitOptions,
+		rs.WithParentResourceID(parentResourceID),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (m *matrixBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return m.resourceType
+}
+
+// List returns the global matrix as a single resource.
+func (m *matrixBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	nr, err := matrixResource(ctx, parentResourceID)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return []*v2.Resource{nr}, "", nil, nil
+}
+
+// Entitlements returns one entitlement for each permission enabled on the controller.
+func (m *matrixBuilder) Entitlements(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	var rv []*v2.Entitlement
+	matrix, err := m.client.GetGlobalMatrix(ctx)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	for _, permission := range matrix.Permissions {
+		rv = append(rv, ent.NewPermissionEntitlement(resource, permission.ID,
+			ent.WithGrantableTo(resourceTypeUser, resourceTypeGroup),
+			ent.WithDisplayName(fmt.Sprintf("%s %s", resource.DisplayName, permission.Name)),
+			ent.WithDescription(fmt.Sprintf("%s permission (%s) granted by the Jenkins global matrix", permission.Name, permission.ID)),
+		))
+	}
+
+	return rv, "", nil, nil
+}
+
+// Grants returns a grant for each user or group entry of the global matrix.
+func (m *matrixBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	var rv []*v2.Grant
+	matrix, err := m.client.GetGlobalMatrix(ctx)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	for _, entry := range matrixEntries(matrix.Entries) {
+		principalId, err := sidResourceId(ctx, entry.sid)
+		if err != nil {
+			return nil, "", nil, fmt.Errorf("error creating principal %s for the global matrix: %w", entry.sid.Sid, err)
+		}
+
+		rv = append(rv, sidGrant(resource, entry.permission, principalId))
+	}
+
+	return rv, "", nil, nil
+}
+
+// hasMatrixEntry reports whether the global matrix grants the permission to the sid, either explicitly as the
+// given sid type or through an ambiguous entry.
+func hasMatrixEntry(matrix *client.MatrixAPIData, entry client.MatrixEntry) bool {
+	return slices.ContainsFunc(matrix.Entries, func(e client.MatrixEntry) bool {
+		return e.Permission == entry.Permission && e.Sid == entry.Sid && (e.Type == entry.Type || e.Type == client.SidTypeEither)
+	})
+}
+
+func (m *matrixBuilder) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+	sidType, err := principalSidType(principal)
+	if err != nil {
+		l.Warn(
+			"jenkins-connector: only users or groups can be granted matrix permissions",
+			zap.String("principal_type", principal.Id.ResourceType),
+			zap.String("principal_id", principal.Id.Resource),
+		)
+		return nil, err
+	}
+
+	entry := client.MatrixEntry{
+		Permission: entitlementSlug(entitlement),
+		Sid:        principal.Id.Resource,
+		Type:       sidType,
+	}
+	matrix, err := m.client.GetGlobalMatrix(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if hasMatrixEntry(matrix, entry) {
+		return nil, fmt.Errorf("jenkins-connector: %s %s already has the %s permission", principal.Id.ResourceType, entry.Sid, entry.Permission)
+	}
+
+	err = m.client.AddGlobalMatrixEntry(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Warn("Matrix permission has been granted.",
+		zap.String("sid", entry.Sid),
+		zap.String("permission", entry.Permission),
+	)
+
+	return nil, nil
+}
+
+func (m *matrixBuilder) Revoke(ctx context.Context, grant *v2.Grant) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+	principal := grant.Principal
+	sidType, err := principalSidType(principal)
+	if err != nil {
+		l.Warn(
+			"jenkins-connector: only users and groups can have matrix permissions revoked",
+			zap.String("principal_type", principal.Id.ResourceType),
+			zap.String("principal_id", principal.Id.Resource),
+		)
+		return nil, err
+	}
+
+	entry := client.MatrixEntry{
+		Permission: entitlementSlug(grant.Entitlement),
+		Sid:        principal.Id.Resource,
+		Type:       sidType,
+	}
+	matrix, err := m.client.GetGlobalMatrix(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hasMatrixEntry(matrix, entry) {
+		return nil, fmt.Errorf("jenkins-connector: %s %s does not have the %s permission", principal.Id.ResourceType, entry.Sid, entry.Permission)
+	}
+
+	err = m.client.RemoveGlobalMatrixEntry(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Warn("Matrix permission has been revoked.",
+		zap.String("sid", entry.Sid),
+		zap.String("permission", entry.Permission),
+	)
+
+	return nil, nil
+}
+
+func newMatrixBuilder(client *client.JenkinsClient) *matrixBuilder {
+	return &matrixBuilder{
+		resourceType: resourceTypeMatrix,
+		client:       client,
+	}
+}
@@ -2,6 +2,7 @@ package connector
 
 import (
 	"context"
+	"strings"
 
 	"github.com/conductorone/baton-jenkins/pkg/client"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
@@ -11,15 +12,44 @@ import (
 )
 
 type nodeBuilder struct {
-	resourceType *v2.ResourceType
-	client       *client.JenkinsClient
+	resourceType          *v2.ResourceType
+	client                *client.JenkinsClient
+	authorizationStrategy string
 }
 
-// Create a new connector resource for a 1Password group.
-func nodeResource(ctx context.Context, role client.Computer, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+// builtInNodeId is the resource ID of the built-in node, whose node name is empty. It is the name Jenkins uses
+// in the URL of the node.
+const builtInNodeId = "(built-in)"
+
+// nodeId returns the resource ID of a node, its node name.
+func nodeId(node client.Computer) string {
+	if node.Name == "" {
+		return builtInNodeId
+	}
+
+	return node.Name
+}
+
+// nodeName returns the node name agent role patterns are matched against.
+func nodeName(resource *v2.Resource) string {
+	if resource.Id.Resource == builtInNodeId {
+		return ""
+	}
+
+	return resource.Id.Resource
+}
+
+// Create a new connector resource for a node.
+func nodeResource(ctx context.Context, node client.Computer, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	labels := make([]string, 0, len(node.AssignedLabels))
+	for _, label := range node.AssignedLabels {
+		labels = append(labels, label.Name)
+	}
+
 	profile := map[string]interface{}{
-		"node_id":   role.AssignedLabels[0].Name,
-		"node_name": role.AssignedLabels[0].Name,
+		"node_id":   nodeId(node),
+		"node_name": node.DisplayName,
+		"labels":    strings.Join(labels, " "),
 	}
 
 	groupTraitOptions := []rs.GroupTraitOption{
@@ -27,9 +57,9 @@ func nodeResource(ctx context.Context, role client.Computer, parentResourceID *v
 	}
 
 	ret, err := rs.NewGroupResource(
-		role.AssignedLabels[0].Name,
+		node.DisplayName,
 		resourceTypeNode,
-		role.AssignedLabels[0].Name,
+		nodeId(node),
 		groupTraitOptions,
 		rs.WithParentResourceID(parentResourceID),
 	)
@@ -64,19 +94,32 @@ func (n *nodeBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId,
 	return rv, "", nil, nil
 }
 
-// Entitlements always returns an empty slice for users.
+// Entitlements returns one permission entitlement for each agent permission.
 func (n *nodeBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
-	return nil, "", nil, nil
+	return permissionEntitlements(resource, nodePermissions), "", nil, nil
 }
 
-// Grants always returns an empty slice for users since they don't have any entitlements.
+// Grants returns the effective agent permissions of users and groups. With Role Strategy they are computed from
+// the global roles and from the agent roles whose pattern matches the node name, with Matrix Authorization
+// from the global matrix.
 func (n *nodeBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
-	return nil, "", nil, nil
+	entries, err := itemEntries(ctx, n.client, n.authorizationStrategy, client.SlaveRoles, nodeName(resource))
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	rv, err := permissionGrants(ctx, resource, nodePermissions, entries)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return rv, "", nil, nil
 }
 
-func newNodeBuilder(client *client.JenkinsClient) *nodeBuilder {
+func newNodeBuilder(client *client.JenkinsClient, authorizationStrategy string) *nodeBuilder {
 	return &nodeBuilder{
-		resourceType: resourceTypeNode,
-		client:       client,
+		resourceType:          resourceTypeNode,
+		client:                client,
+		authorizationStrategy: authorizationStrategy,
 	}
 }
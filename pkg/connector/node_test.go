@@ -0,0 +1,31 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeResource(t *testing.T) {
+	agent := client.Computer{
+		Name:        "linux-agent",
+		DisplayName: "linux-agent",
+		AssignedLabels: []client.AssignedLabels{
+			{Name: "docker"},
+			{Name: "linux-agent"},
+		},
+	}
+	resource, err := nodeResource(ctx, agent, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "linux-agent", resource.Id.Resource)
+	assert.Equal(t, "linux-agent", resource.DisplayName)
+	assert.Equal(t, "linux-agent", nodeName(resource))
+
+	builtIn := client.Computer{DisplayName: "Built-In Node"}
+	resource, err = nodeResource(ctx, builtIn, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, builtInNodeId, resource.Id.Resource)
+	assert.Equal(t, "Built-In Node", resource.DisplayName)
+	assert.Equal(t, "", nodeName(resource))
+}
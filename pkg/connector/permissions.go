@@ -0,0 +1,446 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
+	gr "github.com/conductorone/baton-sdk/pkg/types/grant"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
+
+const administerPermission = "hudson.model.Hudson.Administer"
+
+// Authorization strategies supported by the connector.
+const (
+	roleStrategy          = "role-strategy"
+	matrixStrategy        = "matrix"
+	projectMatrixStrategy = "project-matrix"
+	folderAuthStrategy    = "folder-auth"
+)
+
+var authorizationStrategies = []string{roleStrategy, matrixStrategy, projectMatrixStrategy, folderAuthStrategy}
+
+// isMatrixStrategy reports whether the strategy is one of the Matrix Authorization Strategy variants.
+func isMatrixStrategy(strategy string) bool {
+	return strategy == matrixStrategy || strategy == projectMatrixStrategy
+}
+
+// jenkinsPermission maps a Jenkins permission ID to the entitlement slug used for it.
+type jenkinsPermission struct {
+	id   string
+	slug string
+}
+
+// jobPermissions are the permissions Jenkins checks against individual jobs.
+var jobPermissions = []jenkinsPermission{
+	{id: "hudson.model.Item.Build", slug: "build"},
+	{id: "hudson.model.Item.Cancel", slug: "cancel"},
+	{id: "hudson.model.Item.Configure", slug: "configure"},
+	{id: "hudson.model.Item.Delete", slug: "delete"},
+	{id: "hudson.model.Item.Discover", slug: "discover"},
+	{id: "hudson.model.Item.Move", slug: "move"},
+	{id: "hudson.model.Item.Read", slug: "read"},
+	{id: "hudson.model.Item.Workspace", slug: "workspace"},
+	{id: "hudson.model.Run.Delete", slug: "run-delete"},
+	{id: "hudson.model.Run.Replay", slug: "run-replay"},
+	{id: "hudson.model.Run.Update", slug: "run-update"},
+	{id: "hudson.scm.SCM.Tag", slug: "scm-tag"},
+}
+
+// nodePermissions are the permissions Jenkins checks against individual agents.
+var nodePermissions = []jenkinsPermission{
+	{id: "hudson.model.Computer.Build", slug: "build"},
+	{id: "hudson.model.Computer.Configure", slug: "configure"},
+	{id: "hudson.model.Computer.Connect", slug: "connect"},
+	{id: "hudson.model.Computer.Delete", slug: "delete"},
+	{id: "hudson.model.Computer.Disconnect", slug: "disconnect"},
+	{id: "hudson.model.Computer.ExtendedRead", slug: "extended-read"},
+}
+
+// credentialPermissions are the permissions the Credentials plugin checks against credential stores.
+var credentialPermissions = []jenkinsPermission{
+	{id: "com.cloudbees.plugins.credentials.CredentialsProvider.Create", slug: "create"},
+	{id: "com.cloudbees.plugins.credentials.CredentialsProvider.Delete", slug: "delete"},
+	{id: "com.cloudbees.plugins.credentials.CredentialsProvider.ManageDomains", slug: "manage-domains"},
+	{id: "com.cloudbees.plugins.credentials.CredentialsProvider.Update", slug: "update"},
+	{id: "com.cloudbees.plugins.credentials.CredentialsProvider.UseItem", slug: "use-item"},
+	{id: "com.cloudbees.plugins.credentials.CredentialsProvider.View", slug: "view"},
+}
+
+// impliedPermissions lists, for a permission, the other permissions that directly grant it.
+// Overall/Administer implies every permission and is not listed here.
+var impliedPermissions = map[string][]string{
+	"hudson.model.Item.Cancel":                                   {"hudson.model.Item.Build"},
+	"hudson.model.Item.Discover":                                 {"hudson.model.Item.Read"},
+	"hudson.model.Run.Replay":                                    {"hudson.model.Item.Configure"},
+	"hudson.model.Computer.Connect":                              {"hudson.model.Computer.Disconnect"},
+	"hudson.model.Computer.ExtendedRead":                         {"hudson.model.Computer.Configure"},
+	"com.cloudbees.plugins.credentials.CredentialsProvider.View": {"com.cloudbees.plugins.credentials.CredentialsProvider.Update"},
+}
+
+// implyingPermissions holds, for each permission, every permission granting it directly or transitively.
+var implyingPermissions = permissionClosure(impliedPermissions)
+
+// permissionClosure returns, for each permission with implications, the set of permissions implying it
+// transitively.
+func permissionClosure(implied map[string][]string) map[string]map[string]bool {
+	closure := make(map[string]map[string]bool)
+	for permissionId := range implied {
+		implying := make(map[string]bool)
+		queue := slices.Clone(implied[permissionId])
+		for len(queue) > 0 {
+			permission := queue[0]
+			queue = queue[1:]
+			if implying[permission] || permission == permissionId {
+				continue
+			}
+
+			implying[permission] = true
+			queue = append(queue, implied[permission]...)
+		}
+
+		closure[permissionId] = implying
+	}
+
+	return closure
+}
+
+// permissionEntry is a single permission held by a user or group sid.
+type permissionEntry struct {
+	permission string
+	sid        client.Role
+}
+
+// grantsPermission reports whether holding the entry's permission grants the given permission ID.
+func (p permissionEntry) grantsPermission(permissionId string) bool {
+	if p.permission == permissionId || p.permission == administerPermission {
+		return true
+	}
+
+	return implyingPermissions[permissionId][p.permission]
+}
+
+// patternMatches reports whether a Role Strategy pattern matches the whole name, the way the plugin does.
+func patternMatches(ctx context.Context, pattern, name string) bool {
+	re, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", pattern))
+	if err != nil {
+		ctxzap.Extract(ctx).Warn("jenkins-connector: skipping role with unsupported pattern",
+			zap.String("pattern", pattern),
+			zap.Error(err),
+		)
+		return false
+	}
+
+	return re.MatchString(name)
+}
+
+// roleEntries expands the given roles into permission entries. Global roles always apply, while roles of
+// patternRoleType only apply when their pattern matches name.
+func roleEntries(ctx context.Context, roles []client.RolesAPIData, patternRoleType, name string) []permissionEntry {
+	var entries []permissionEntry
+	for _, role := range roles {
+		switch role.RoleType {
+		case client.GlobalRoles:
+		case patternRoleType:
+			if !patternMatches(ctx, role.Pattern, name) {
+				continue
+			}
+		default:
+			continue
+		}
+
+		for _, permission := range role.Permissions {
+			for _, sid := range role.RoleDetail {
+				entries = append(entries, permissionEntry{
+					permission: permission,
+					sid:        sid,
+				})
+			}
+		}
+	}
+
+	return entries
+}
+
+// matrixEntries converts matrix entries into permission entries.
+func matrixEntries(entries []client.MatrixEntry) []permissionEntry {
+	var rv []permissionEntry
+	for _, entry := range entries {
+		rv = append(rv, permissionEntry{
+			permission: entry.Permission,
+			sid: client.Role{
+				Sid:  entry.Sid,
+				Type: entry.Type,
+			},
+		})
+	}
+
+	return rv
+}
+
+// itemEntries returns the permission entries that apply to a job, folder or agent under the authorization strategy.
+// With Role Strategy, patternRoleType selects the roles whose pattern is matched against name. With project-based
+// matrix authorization, the matrix of jobs and folders is combined with the matrices they inherit. With folder-based
+// authorization, patternRoleType selects whether folder or agent roles are matched against name.
+func itemEntries(ctx context.Context, c *client.JenkinsClient, strategy, patternRoleType, name string) ([]permissionEntry, error) {
+	switch strategy {
+	case projectMatrixStrategy:
+		if patternRoleType == client.ProjectRoles {
+			return projectMatrixEntries(ctx, c, name)
+		}
+
+		fallthrough
+	case matrixStrategy:
+		matrix, err := c.GetGlobalMatrix(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return matrixEntries(matrix.Entries), nil
+	case folderAuthStrategy:
+		roles, err := c.GetAllFolderAuthRoles(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return folderAuthEntries(roles, knownGroups(ctx, c), patternRoleType, name), nil
+	default:
+		roles, err := c.GetAllRoleDefinitions(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		return roleEntries(ctx, roles, patternRoleType, name), nil
+	}
+}
+
+// isUserSid reports whether a sid refers to the user, explicitly or through an ambiguous entry.
+func isUserSid(sid client.Role, userId string) bool {
+	return sid.Sid == userId && sid.Type != client.SidTypeGroup
+}
+
+// removeUserPermissions removes every role assignment or matrix entry of a user under the authorization strategy,
+// so that no orphaned sid is left behind once the user is deleted.
+func removeUserPermissions(ctx context.Context, c *client.JenkinsClient, strategy, userId string) error {
+	l := ctxzap.Extract(ctx)
+	switch strategy {
+	case folderAuthStrategy:
+		roles, err := c.GetAllFolderAuthRoles(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, role := range roles {
+			if !slices.Contains(role.Sids, userId) {
+				continue
+			}
+
+			if _, err := c.RemoveSidFromFolderAuthRole(ctx, role.Type, role.Name, userId); err != nil {
+				return err
+			}
+
+			l.Warn("Role has been revoked.",
+				zap.String("roleType", role.Type),
+				zap.String("roleId", role.Name),
+				zap.String("userId", userId),
+			)
+		}
+	case matrixStrategy, projectMatrixStrategy:
+		matrix, err := c.GetGlobalMatrix(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range matrix.Entries {
+			if !isUserSid(client.Role{Sid: entry.Sid, Type: entry.Type}, userId) {
+				continue
+			}
+
+			if err := c.RemoveGlobalMatrixEntry(ctx, entry); err != nil {
+				return err
+			}
+
+			l.Warn("Matrix permission has been revoked.",
+				zap.String("sid", entry.Sid),
+				zap.String("permission", entry.Permission),
+			)
+		}
+
+		if strategy == matrixStrategy {
+			return nil
+		}
+
+		items, err := allItems(ctx, c, "")
+		if err != nil {
+			return err
+		}
+
+		for _, item := range items {
+			itemMatrix, err := c.GetItemMatrix(ctx, item.FullName)
+			if err != nil {
+				return err
+			}
+
+			if itemMatrix == nil {
+				continue
+			}
+
+			for _, entry := range itemMatrix.Entries {
+				if !isUserSid(client.Role{Sid: entry.Sid, Type: entry.Type}, userId) {
+					continue
+				}
+
+				if err := c.RemoveItemMatrixEntry(ctx, item.FullName, entry); err != nil {
+					return err
+				}
+
+				l.Warn("Item permission has been revoked.",
+					zap.String("item", item.FullName),
+					zap.String("sid", entry.Sid),
+					zap.String("permission", entry.Permission),
+				)
+			}
+		}
+	default:
+		roles, err := c.GetAllRoleDefinitions(ctx)
+		if err != nil {
+			return err
+		}
+
+		for _, role := range roles {
+			for _, sid := range role.RoleDetail {
+				if !isUserSid(sid, userId) {
+					continue
+				}
+
+				if sid.Type == client.SidTypeEither {
+					_, err = c.UnassignRole(ctx, role.RoleType, role.RoleName, sid.Sid)
+				} else {
+					_, err = c.UnassignUserRole(ctx, role.RoleType, role.RoleName, sid.Sid)
+				}
+				if err != nil {
+					return err
+				}
+
+				l.Warn("Role has been revoked.",
+					zap.String("roleType", role.RoleType),
+					zap.String("roleId", role.RoleName),
+					zap.String("userId", userId),
+				)
+			}
+		}
+	}
+
+	return nil
+}
+
+// knownGroups returns the groups the security realm granted to users, which are the groups synced besides the
+// built-in one. They are read through the script console; without it sids which do not say whether they refer to
+// a user or a group are resolved as users.
+func knownGroups(ctx context.Context, c *client.JenkinsClient) map[string]bool {
+	groups := make(map[string]bool)
+	realmGroups, err := c.GetAuthorityGroups(ctx)
+	if err != nil {
+		ctxzap.Extract(ctx).Debug("jenkins-connector: unable to read the groups of the security realm, sids of either type are resolved as users",
+			zap.Error(err),
+		)
+		return groups
+	}
+
+	for _, group := range realmGroups {
+		groups[group.ID] = true
+	}
+
+	return groups
+}
+
+// resolveSid returns a sid which does not say whether it refers to a user or a group as a group when it names one
+// of the known groups. Other sids are returned unchanged.
+func resolveSid(sid client.Role, groups map[string]bool) client.Role {
+	if sid.Type == client.SidTypeEither && groups[sid.Sid] {
+		return client.Role{Sid: sid.Sid, Type: client.SidTypeGroup}
+	}
+
+	return sid
+}
+
+// sidResourceId returns the user or group resource ID of a sid. The built-in principals are recognized by name,
+// while other sids which do not say whether they refer to a user or a group are treated as users.
+func sidResourceId(ctx context.Context, sid client.Role) (*v2.ResourceId, error) {
+	if sid.Sid == authenticatedGroup || (sid.Type == client.SidTypeGroup && sid.Sid != anonymousUser) {
+		groupRes, err := groupResource(ctx, client.Group{ID: sid.Sid}, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		return groupRes.Id, nil
+	}
+
+	ur, err := userResource(ctx, client.Users{User: client.User{ID: sid.Sid}}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return ur.Id, nil
+}
+
+// sidGrant creates a grant of an entitlement to a user or group. Grants to groups expand to the members of the group.
+func sidGrant(resource *v2.Resource, slug string, principalId *v2.ResourceId) *v2.Grant {
+	if principalId.ResourceType != resourceTypeGroup.Id {
+		return gr.NewGrant(resource, slug, principalId)
+	}
+
+	return gr.NewGrant(resource, slug, principalId, gr.WithAnnotation(&v2.GrantExpandable{
+		EntitlementIds: []string{fmt.Sprintf("%s:%s:%s", resourceTypeGroup.Id, principalId.Resource, memberEntitlement)},
+	}))
+}
+
+// permissionEntitlements creates one permission entitlement per Jenkins permission for the resource.
+func permissionEntitlements(resource *v2.Resource, permissions []jenkinsPermission) []*v2.Entitlement {
+	var rv []*v2.Entitlement
+	resourceType := strings.ReplaceAll(resource.Id.ResourceType, "_", " ")
+	for _, permission := range permissions {
+		rv = append(rv, ent.NewPermissionEntitlement(resource, permission.slug,
+			ent.WithGrantableTo(resourceTypeUser, resourceTypeGroup),
+			ent.WithDisplayName(fmt.Sprintf("%s %s %s", resource.DisplayName, titleCase(resourceType), titleCase(permission.slug))),
+			ent.WithDescription(fmt.Sprintf("%s permission (%s) on Jenkins %s %s", titleCase(permission.slug), permission.id, resourceType, resource.DisplayName)),
+		))
+	}
+
+	return rv
+}
+
+// permissionGrants creates a grant for every sid holding one of the permissions on the resource.
+func permissionGrants(ctx context.Context, resource *v2.Resource, permissions []jenkinsPermission, entries []permissionEntry) ([]*v2.Grant, error) {
+	var rv []*v2.Grant
+	for _, permission := range permissions {
+		granted := make(map[string]bool)
+		for _, entry := range entries {
+			if !entry.grantsPermission(permission.id) {
+				continue
+			}
+
+			principalId, err := sidResourceId(ctx, entry.sid)
+			if err != nil {
+				return nil, fmt.Errorf("error creating principal %s for %s %s: %w", entry.sid.Sid, resource.Id.ResourceType, resource.Id.Resource, err)
+			}
+
+			key := fmt.Sprintf("%s:%s", principalId.ResourceType, principalId.Resource)
+			if granted[key] {
+				continue
+			}
+
+			granted[key] = true
+			rv = append(rv, sidGrant(resource, permission.slug, principalId))
+		}
+	}
+
+	return rv, nil
+}
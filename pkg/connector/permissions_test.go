@@ -0,0 +1,90 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleEntries(t *testing.T) {
+	roles := []client.RolesAPIData{
+		{
+			RoleName:    "admin",
+			RoleType:    client.GlobalRoles,
+			RoleDetail:  []client.Role{{Sid: "admin", Type: "USER"}},
+			Permissions: []string{administerPermission},
+		},
+		{
+			RoleName:    "deployers",
+			RoleType:    client.ProjectRoles,
+			RoleDetail:  []client.Role{{Sid: "ops", Type: "GROUP"}},
+			Permissions: []string{"hudson.model.Item.Build"},
+			Pattern:     "deploy-.*",
+		},
+	}
+
+	entries := roleEntries(ctx, roles, client.ProjectRoles, "deploy-prod")
+	assert.Len(t, entries, 2)
+	entries = roleEntries(ctx, roles, client.ProjectRoles, "build-deploy-prod")
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "admin", entries[0].sid.Sid)
+}
+
+func TestPermissionEntryGrantsPermission(t *testing.T) {
+	build := permissionEntry{permission: "hudson.model.Item.Build"}
+	assert.True(t, build.grantsPermission("hudson.model.Item.Build"))
+	assert.True(t, build.grantsPermission("hudson.model.Item.Cancel"))
+	assert.False(t, build.grantsPermission("hudson.model.Item.Configure"))
+
+	configure := permissionEntry{permission: "hudson.model.Item.Configure"}
+	assert.True(t, configure.grantsPermission("hudson.model.Run.Replay"))
+	assert.False(t, configure.grantsPermission("hudson.model.Item.Build"))
+
+	admin := permissionEntry{permission: administerPermission}
+	assert.True(t, admin.grantsPermission("hudson.model.Item.Configure"))
+}
+
+func TestSidGrantExpandsGroups(t *testing.T) {
+	job, err := jobResource(ctx, client.Job{Name: "deploy", FullName: "deploy"}, nil)
+	assert.Nil(t, err)
+
+	groupGrant := sidGrant(job, "build", &v2.ResourceId{ResourceType: resourceTypeGroup.Id, Resource: "ops"})
+	expandable := &v2.GrantExpandable{}
+	annos := annotations.Annotations(groupGrant.Annotations)
+	ok, err := annos.Pick(expandable)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"group:ops:member"}, expandable.EntitlementIds)
+
+	userGrant := sidGrant(job, "build", &v2.ResourceId{ResourceType: resourceTypeUser.Id, Resource: "jane"})
+	assert.Empty(t, userGrant.Annotations)
+}
+
+func TestSidResourceIdBuiltIns(t *testing.T) {
+	id, err := sidResourceId(ctx, client.Role{Sid: authenticatedGroup, Type: client.SidTypeEither})
+	assert.Nil(t, err)
+	assert.Equal(t, resourceTypeGroup.Id, id.ResourceType)
+
+	id, err = sidResourceId(ctx, client.Role{Sid: anonymousUser, Type: client.SidTypeGroup})
+	assert.Nil(t, err)
+	assert.Equal(t, resourceTypeUser.Id, id.ResourceType)
+
+	id, err = sidResourceId(ctx, client.Role{Sid: "ops", Type: client.SidTypeGroup})
+	assert.Nil(t, err)
+	assert.Equal(t, resourceTypeGroup.Id, id.ResourceType)
+}
+
+func TestPermissionClosure(t *testing.T) {
+	closure := permissionClosure(map[string][]string{
+		"hudson.model.Item.Discover": {"hudson.model.Item.Read"},
+		"hudson.model.Item.Read":     {"hudson.model.Item.Configure"},
+		"hudson.model.Item.Cancel":   {"hudson.model.Item.Build", "hudson.model.Item.Cancel"},
+	})
+
+	assert.Equal(t, map[string]bool{"hudson.model.Item.Read": true, "hudson.model.Item.Configure": true}, closure["hudson.model.Item.Discover"])
+	assert.Equal(t, map[string]bool{"hudson.model.Item.Configure": true}, closure["hudson.model.Item.Read"])
+	assert.Equal(t, map[string]bool{"hudson.model.Item.Build": true}, closure["hudson.model.Item.Cancel"])
+}
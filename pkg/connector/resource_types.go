@@ -27,6 +27,13 @@ var (
 			v2.ResourceType_TRAIT_GROUP,
 		},
 	}
+	resourceTypeFolder = &v2.ResourceType{
+		Id:          "folder",
+		DisplayName: "Folder",
+		Traits: []v2.ResourceType_Trait{
+			v2.ResourceType_TRAIT_GROUP,
+		},
+	}
 	resourceTypeView = &v2.ResourceType{
 		Id:          "view",
 		DisplayName: "View",
@@ -41,6 +48,32 @@ var (
 			v2.ResourceType_TRAIT_GROUP,
 		},
 	}
+	resourceTypeMatrix = &v2.ResourceType{
+		Id:          "matrix",
+		DisplayName: "Authorization Matrix",
+		Traits: []v2.ResourceType_Trait{
+			v2.ResourceType_TRAIT_GROUP,
+		},
+	}
+	resourceTypeToken = &v2.ResourceType{
+		Id:          "token",
+		DisplayName: "API Token",
+	}
+	resourceTypeCredentialStore = &v2.ResourceType{
+		Id:          "credential_store",
+		DisplayName: "Credential Store",
+		Traits: []v2.ResourceType_Trait{
+			v2.ResourceType_TRAIT_GROUP,
+		},
+	}
+	resourceTypeCredentialDomain = &v2.ResourceType{
+		Id:          "credential_domain",
+		DisplayName: "Credential Domain",
+	}
+	resourceTypeCredential = &v2.ResourceType{
+		Id:          "credential",
+		DisplayName: "Credential",
+	}
 	resourceTypeGroup = &v2.ResourceType{
 		Id:          "group",
 		DisplayName: "Group",
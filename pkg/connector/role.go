@@ -5,13 +5,13 @@ import (
 	"fmt"
 	"net/http"
 	"slices"
+	"strings"
 
 	"github.com/conductorone/baton-jenkins/pkg/client"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/annotations"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
 	ent "github.com/conductorone/baton-sdk/pkg/types/entitlement"
-	gr "github.com/conductorone/baton-sdk/pkg/types/grant"
 	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
 	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
 	"go.uber.org/zap"
@@ -24,11 +24,56 @@ type roleBuilder struct {
 
 const NF = -1
 
+// roleTypeNames maps the Role Strategy role types to the short names used in role resource IDs.
+var roleTypeNames = map[string]string{
+	client.GlobalRoles:  "global",
+	client.ProjectRoles: "project",
+	client.SlaveRoles:   "agent",
+}
+
+// roleResourceID returns the resource ID for a role, namespaced by its role type so that
+// global, project and agent roles sharing a name do not collide.
+func roleResourceID(roleType, roleName string) string {
+	return fmt.Sprintf("%s:%s", roleTypeNames[roleType], roleName)
+}
+
+// parseRoleResourceID splits a role resource ID into its Role Strategy role type and role name.
+func parseRoleResourceID(id string) (string, string, error) {
+	typeName, roleName, ok := strings.Cut(id, ":")
+	if !ok || roleName == "" {
+		return "", "", fmt.Errorf("jenkins-connector: invalid role id %s", id)
+	}
+
+	roleType, ok := roleTypeFromName(typeName)
+	if !ok {
+		return "", "", fmt.Errorf("jenkins-connector: invalid role type %s in role id %s", typeName, id)
+	}
+
+	return roleType, roleName, nil
+}
+
+// roleTypeFromName returns the Role Strategy role type for a short role type name (global, project or agent).
+func roleTypeFromName(typeName string) (string, bool) {
+	for roleType, name := range roleTypeNames {
+		if name == typeName {
+			return roleType, true
+		}
+	}
+
+	return "", false
+}
+
 // Create a new connector resource for a jenkins role.
-func roleResource(ctx context.Context, role string, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+func roleResource(ctx context.Context, role client.RolesAPIData, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	typeName := roleTypeNames[role.RoleType]
 	profile := map[string]interface{}{
-		"node_id":   role,
-		"node_name": role,
+		"node_id":     role.RoleName,
+		"node_name":   role.RoleName,
+		"role_type":   typeName,
+		"permissions": strings.Join(role.Permissions, ","),
+	}
+	if role.Pattern != "" {
+		profile["pattern"] = role.Pattern
 	}
 
 	groupTraitOptions := []rs.GroupTraitOption{
@@ -36,9 +81,9 @@ func roleResource(ctx context.Context, role string, parentResourceID *v2.Resourc
 	}
 
 	ret, err := rs.NewGroupResource(
-		role,
+		fmt.Sprintf("%s (%s)", role.RoleName, typeName),
 		resourceTypeRole,
-		role,
+		roleResourceID(role.RoleType, role.RoleName),
 		groupTraitOptions,
 		rs.WithParentResourceID(parentResourceID),
 	)
@@ -59,13 +104,13 @@ func (r *roleBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId,
 	var (
 		rv []*v2.Resource
 	)
-	roles, err := r.client.GetAllRoles(ctx)
+	roles, err := r.client.GetAllRoleDefinitions(ctx)
 	if err != nil {
 		return nil, "", nil, err
 	}
 
 	for _, role := range roles {
-		nr, err := roleResource(ctx, role.RoleName, parentResourceID)
+		nr, err := roleResource(ctx, role, parentResourceID)
 		if err != nil {
 			return nil, "", nil, err
 		}
@@ -78,13 +123,16 @@ func (r *roleBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId,
 // Entitlements always returns an empty slice for users.
 func (r *roleBuilder) Entitlements(ctx context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
 	var rv []*v2.Entitlement
-	// role does not have an id
-	permission := resource.DisplayName
+	roleType, permission, err := parseRoleResourceID(resource.Id.Resource)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
 	// create entitlement for each role
 	permissionOptions := []ent.EntitlementOption{
 		ent.WithGrantableTo(resourceTypeUser, resourceTypeGroup),
 		ent.WithDisplayName(fmt.Sprintf("%s Role %s", resource.DisplayName, permission)),
-		ent.WithDescription(fmt.Sprintf("%s access to %s - %s role in Jenkins", titleCase(permission), resource.Id.Resource, resource.DisplayName)),
+		ent.WithDescription(fmt.Sprintf("%s access to %s - %s %s role in Jenkins", titleCase(permission), resource.Id.Resource, permission, roleTypeNames[roleType])),
 	}
 	rv = append(rv, ent.NewPermissionEntitlement(
 		resource,
@@ -98,10 +146,9 @@ func (r *roleBuilder) Entitlements(ctx context.Context, resource *v2.Resource, _
 // Grants always returns an empty slice for users since they don't have any entitlements.
 func (r *roleBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
 	var (
-		err       error
-		rv        []*v2.Grant
-		userType  = "USER"
-		groupType = "GROUP"
+		err    error
+		rv     []*v2.Grant
+		groups map[string]bool
 	)
 	roles, err := r.client.GetAllRoles(ctx)
 	if err != nil {
@@ -109,45 +156,32 @@ func (r *roleBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken
 	}
 
 	for _, role := range roles {
-		if role.RoleName != resource.Id.Resource {
+		if roleResourceID(role.RoleType, role.RoleName) != resource.Id.Resource {
 			continue
 		}
 
 		for _, rd := range role.RoleDetail {
-			switch rd.Type {
-			case userType:
-				user := client.Users{
-					User: client.User{
-						ID: rd.Sid,
-					},
-				}
-				ur, err := userResource(ctx, user, resource.Id)
-				if err != nil {
-					return nil, "", nil, fmt.Errorf("error creating user resource for role %s: %w", resource.Id.Resource, err)
-				}
-
-				tr := gr.NewGrant(resource, role.RoleName, ur.Id)
-				rv = append(rv, tr)
-			case groupType:
-				group := client.Group{
-					ID: rd.Sid,
-				}
-				ur, err := groupResource(ctx, group, resource.Id)
-				if err != nil {
-					return nil, "", nil, fmt.Errorf("error creating group resource for role %s: %w", resource.Id.Resource, err)
-				}
-
-				tr := gr.NewGrant(resource, role.RoleName, ur.Id)
-				rv = append(rv, tr)
+			// Sids assigned before Role Strategy typed them do not say whether they refer to a user or a group, they are
+			// resolved against the known groups.
+			if rd.Type == client.SidTypeEither && groups == nil {
+				groups = knownGroups(ctx, r.client)
+			}
+
+			principalId, err := sidResourceId(ctx, resolveSid(rd, groups))
+			if err != nil {
+				return nil, "", nil, fmt.Errorf("error creating principal resource for role %s: %w", resource.Id.Resource, err)
 			}
+
+			rv = append(rv, sidGrant(resource, role.RoleName, principalId))
 		}
 	}
 
 	return rv, "", nil, nil
 }
 
-func validateRole(ctx context.Context, r *roleBuilder, roleId, sId string) (int, error) {
-	roles, err := r.client.GetAllRoles(ctx)
+// validateRole looks up the role and returns the position of sId among its members.
+func validateRole(ctx context.Context, r *roleBuilder, roleType, roleId, sId string) (int, error) {
+	roles, err := r.client.GetRoles(ctx, roleType)
 	if err != nil {
 		return NF, err
 	}
@@ -164,11 +198,10 @@ func validateRole(ctx context.Context, r *roleBuilder, roleId, sId string) (int,
 		return rolePos, nil
 	}
 
-	return NF, nil
+	return NF, fmt.Errorf("jenkins-connector: role %s not found", roleId)
 }
 
 func (r *roleBuilder) Grant(ctx context.Context, principal *v2.Resource, entitlement *v2.Entitlement) (annotations.Annotations, error) {
-	var roleId = entitlement.Resource.Id.Resource
 	l := ctxzap.Extract(ctx)
 	if principal.Id.ResourceType != resourceTypeUser.Id && principal.Id.ResourceType != resourceTypeGroup.Id {
 		l.Warn(
@@ -179,10 +212,15 @@ func (r *roleBuilder) Grant(ctx context.Context, principal *v2.Resource, entitle
 		return nil, fmt.Errorf("jenkins-connector: only users or groups can be granted role memberships")
 	}
 
+	roleType, roleId, err := parseRoleResourceID(entitlement.Resource.Id.Resource)
+	if err != nil {
+		return nil, err
+	}
+
 	switch principal.Id.ResourceType {
 	case resourceTypeUser.Id:
 		userId := principal.Id.Resource
-		rolePos, err := validateRole(ctx, r, roleId, userId)
+		rolePos, err := validateRole(ctx, r, roleType, roleId, userId)
 		if err != nil {
 			return nil, err
 		}
@@ -196,7 +234,7 @@ func (r *roleBuilder) Grant(ctx context.Context, principal *v2.Resource, entitle
 			return nil, fmt.Errorf("jenkins-connector: user %s already has this role permission", userId)
 		}
 
-		statusCode, err := r.client.AssignUserRole(ctx, roleId, userId)
+		statusCode, err := r.client.AssignUserRole(ctx, roleType, roleId, userId)
 		if err != nil {
 			return nil, err
 		}
@@ -209,7 +247,7 @@ func (r *roleBuilder) Grant(ctx context.Context, principal *v2.Resource, entitle
 		}
 	case resourceTypeGroup.Id:
 		groupId := principal.Id.Resource
-		rolePos, err := validateRole(ctx, r, roleId, groupId)
+		rolePos, err := validateRole(ctx, r, roleType, roleId, groupId)
 		if err != nil {
 			return nil, err
 		}
@@ -223,7 +261,7 @@ func (r *roleBuilder) Grant(ctx context.Context, principal *v2.Resource, entitle
 			return nil, fmt.Errorf("jenkins-connector: group %s already has this role permission", groupId)
 		}
 
-		statusCode, err := r.client.AssignGroupRole(ctx, roleId, groupId)
+		statusCode, err := r.client.AssignGroupRole(ctx, roleType, roleId, groupId)
 		if err != nil {
 			return nil, err
 		}
@@ -262,11 +300,15 @@ func (r *roleBuilder) Revoke(ctx context.Context, grant *v2.Grant) (annotations.
 		return nil, err
 	}
 
-	roleId := entitlement.Resource.Id.Resource
+	roleType, roleId, err := parseRoleResourceID(entitlement.Resource.Id.Resource)
+	if err != nil {
+		return nil, err
+	}
+
 	switch principal.Id.ResourceType {
 	case resourceTypeUser.Id:
 		userId := principal.Id.Resource
-		rolePos, err := validateRole(ctx, r, roleId, userId)
+		rolePos, err := validateRole(ctx, r, roleType, roleId, userId)
 		if err != nil {
 			return nil, err
 		}
@@ -280,7 +322,7 @@ func (r *roleBuilder) Revoke(ctx context.Context, grant *v2.Grant) (annotations.
 			return nil, fmt.Errorf("jenkins-connector: user %s does not have this role", userId)
 		}
 
-		statusCode, err := r.client.UnassignUserRole(ctx, roleId, userId)
+		statusCode, err := r.client.UnassignUserRole(ctx, roleType, roleId, userId)
 		if err != nil {
 			return nil, err
 		}
@@ -293,7 +335,7 @@ func (r *roleBuilder) Revoke(ctx context.Context, grant *v2.Grant) (annotations.
 		}
 	case resourceTypeGroup.Id:
 		groupId := principal.Id.Resource
-		rolePos, err := validateRole(ctx, r, roleId, groupId)
+		rolePos, err := validateRole(ctx, r, roleType, roleId, groupId)
 		if err != nil {
 			return nil, err
 		}
@@ -307,7 +349,7 @@ func (r *roleBuilder) Revoke(ctx context.Context, grant *v2.Grant) (annotations.
 			return nil, fmt.Errorf("jenkins-connector: group %s does not have this role", groupId)
 		}
 
-		statusCode, err := r.client.UnassignGroupRole(ctx, roleId, groupId)
+		statusCode, err := r.client.UnassignGroupRole(ctx, roleType, roleId, groupId)
 		if err != nil {
 			return nil, err
 		}
@@ -325,6 +367,104 @@ func (r *roleBuilder) Revoke(ctx context.Context, grant *v2.Grant) (annotations.
 	return nil, nil
 }
 
+// Create adds a new Role Strategy role. The role name is taken from the resource display name, while the
+// role type (global, project or agent), the comma separated permission IDs and the pattern are read from
+// the role_type, permissions and pattern fields of the resource profile.
+func (r *roleBuilder) Create(ctx context.Context, resource *v2.Resource) (*v2.Resource, annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+	groupTrait, err := rs.GetGroupTrait(resource)
+	if err != nil {
+		return nil, nil, fmt.Errorf("jenkins-connector: role resource must have a profile: %w", err)
+	}
+
+	typeName, _ := rs.GetProfileStringValue(groupTrait.Profile, "role_type")
+	roleType, ok := roleTypeFromName(typeName)
+	if !ok {
+		return nil, nil, fmt.Errorf("jenkins-connector: invalid role type %q, expected global, project or agent", typeName)
+	}
+
+	// The display name of synced roles carries their type, the name is read from the profile when it is there.
+	roleName, ok := rs.GetProfileStringValue(groupTrait.Profile, "node_name")
+	if !ok || roleName == "" {
+		roleName = resource.DisplayName
+	}
+	if roleName == "" {
+		return nil, nil, fmt.Errorf("jenkins-connector: role name is required")
+	}
+
+	pattern, _ := rs.GetProfileStringValue(groupTrait.Profile, "pattern")
+	if roleType != client.GlobalRoles && pattern == "" {
+		return nil, nil, fmt.Errorf("jenkins-connector: a pattern is required for %s roles", typeName)
+	}
+
+	var permissions []string
+	permissionList, _ := rs.GetProfileStringValue(groupTrait.Profile, "permissions")
+	for _, permission := range strings.Split(permissionList, ",") {
+		if permission = strings.TrimSpace(permission); permission != "" {
+			permissions = append(permissions, permission)
+		}
+	}
+
+	roles, err := r.client.GetRoles(ctx, roleType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if slices.ContainsFunc(roles, func(role client.RolesAPIData) bool {
+		return role.RoleName == roleName
+	}) {
+		return nil, nil, fmt.Errorf("jenkins-connector: %s role %s already exists", typeName, roleName)
+	}
+
+	statusCode, err := r.client.AddRole(ctx, roleType, roleName, permissions, pattern)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if statusCode == http.StatusOK {
+		l.Warn("Role has been created.",
+			zap.String("roleType", roleType),
+			zap.String("roleId", roleName),
+		)
+	}
+
+	role := client.RolesAPIData{
+		RoleName:    roleName,
+		RoleType:    roleType,
+		Permissions: permissions,
+		Pattern:     pattern,
+	}
+	nr, err := roleResource(ctx, role, resource.ParentResourceId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nr, nil, nil
+}
+
+// Delete removes a Role Strategy role together with all of its assignments.
+func (r *roleBuilder) Delete(ctx context.Context, resourceId *v2.ResourceId) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+	roleType, roleName, err := parseRoleResourceID(resourceId.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	statusCode, err := r.client.RemoveRoles(ctx, roleType, roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	if statusCode == http.StatusOK {
+		l.Warn("Role has been deleted.",
+			zap.String("roleType", roleType),
+			zap.String("roleId", roleName),
+		)
+	}
+
+	return nil, nil
+}
+
 func newRoleBuilder(client *client.JenkinsClient) *roleBuilder {
 	return &roleBuilder{
 		resourceType: resourceTypeRole,
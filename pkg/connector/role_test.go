@@ -0,0 +1,102 @@
+package connector
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleResourceID(t *testing.T) {
+	for _, roleType := range client.RoleTypes {
+		id := roleResourceID(roleType, "admin")
+		parsedType, parsedName, err := parseRoleResourceID(id)
+		assert.Nil(t, err)
+		assert.Equal(t, roleType, parsedType)
+		assert.Equal(t, "admin", parsedName)
+	}
+
+	assert.NotEqual(t, roleResourceID(client.GlobalRoles, "admin"), roleResourceID(client.ProjectRoles, "admin"))
+	_, _, err := parseRoleResourceID("admin")
+	assert.NotNil(t, err)
+	_, _, err = parseRoleResourceID("unknown:admin")
+	assert.NotNil(t, err)
+}
+
+func TestCreateRoleFromRoleResource(t *testing.T) {
+	var created string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/crumbIssuer/api/json":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"crumb":"crumb","crumbRequestField":"Jenkins-Crumb"}`))
+		case "/role-strategy/strategy/getAllRoles":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{}`))
+		case "/role-strategy/strategy/addRole":
+			assert.Nil(t, r.ParseForm())
+			created = r.PostForm.Get("roleName")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := client.New(ctx, server.URL, client.NewClient().WithUser("admin").WithPassword("admin"))
+	assert.Nil(t, err)
+
+	resource, err := roleResource(ctx, client.RolesAPIData{
+		RoleName:    "developers",
+		RoleType:    client.ProjectRoles,
+		Permissions: []string{"hudson.model.Item.Build"},
+		Pattern:     "app-.*",
+	}, nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "developers (project)", resource.DisplayName)
+
+	role, _, err := newRoleBuilder(c).Create(ctx, resource)
+	assert.Nil(t, err)
+	assert.Equal(t, "developers", created)
+	assert.Equal(t, roleResourceID(client.ProjectRoles, "developers"), role.Id.Resource)
+}
+
+func TestRoleGrantsResolveEitherSids(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/role-strategy/strategy/getAllRoles":
+			w.Header().Set("Content-Type", "application/json")
+			if r.URL.Query().Get("type") != client.GlobalRoles {
+				_, _ = w.Write([]byte(`{}`))
+				return
+			}
+
+			_, _ = w.Write([]byte(`{"admin":[{"sid":"jane","type":"EITHER"},{"sid":"developers","type":"EITHER"},{"sid":"ops","type":"GROUP"}]}`))
+		case "/scriptText":
+			_, _ = w.Write([]byte(`["developers"]` + "\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := client.New(ctx, server.URL, client.NewClient().WithUser("admin").WithPassword("admin"))
+	assert.Nil(t, err)
+
+	resource, err := roleResource(ctx, client.RolesAPIData{RoleName: "admin", RoleType: client.GlobalRoles}, nil)
+	assert.Nil(t, err)
+
+	grants, _, _, err := newRoleBuilder(c).Grants(ctx, resource, nil)
+	assert.Nil(t, err)
+	assert.Len(t, grants, 3)
+	principals := make(map[string]string)
+	for _, grant := range grants {
+		principals[grant.Principal.Id.Resource] = grant.Principal.Id.ResourceType
+	}
+	assert.Equal(t, map[string]string{
+		"jane":       resourceTypeUser.Id,
+		"developers": resourceTypeGroup.Id,
+		"ops":        resourceTypeGroup.Id,
+	}, principals)
+}
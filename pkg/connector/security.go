@@ -0,0 +1,247 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
+)
+
+// Security realms the connector tells apart.
+const (
+	localRealm           = "local"
+	ldapRealm            = "ldap"
+	activeDirectoryRealm = "active-directory"
+	externalRealm        = "external"
+)
+
+// authorizationStrategyClasses maps the Jenkins authorization strategy classes to the supported strategies.
+var authorizationStrategyClasses = map[string]string{
+	"com.michelin.cio.hudson.plugins.rolestrategy.RoleBasedAuthorizationStrategy": roleStrategy,
+	"hudson.security.GlobalMatrixAuthorizationStrategy":                           matrixStrategy,
+	"hudson.security.ProjectMatrixAuthorizationStrategy":                          projectMatrixStrategy,
+	"io.jenkins.plugins.folderauth.FolderBasedAuthorizationStrategy":              folderAuthStrategy,
+}
+
+// strategyPlugins maps the authorization strategies to the plugin providing them.
+var strategyPlugins = map[string]string{
+	roleStrategy:          "role-strategy",
+	matrixStrategy:        "matrix-auth",
+	projectMatrixStrategy: "matrix-auth",
+	folderAuthStrategy:    "folder-auth",
+}
+
+// peopleViewPlugin provides the People API used to list users since it was removed from Jenkins 2.452.
+const peopleViewPlugin = "people-view"
+
+// securityRealmClasses maps the Jenkins security realm classes to the realms the connector tells apart.
+// Every other realm is an external one.
+var securityRealmClasses = map[string]string{
+	"hudson.security.HudsonPrivateSecurityRealm":                   localRealm,
+	"hudson.security.LDAPSecurityRealm":                            ldapRealm,
+	"hudson.plugins.active_directory.ActiveDirectorySecurityRealm": activeDirectoryRealm,
+}
+
+// securityConfiguration is what the connector detected about the controller.
+type securityConfiguration struct {
+	authorizationStrategy string
+	securityRealm         string
+	// plugins holds the short names of the active plugins.
+	plugins map[string]bool
+	// scriptConsole is set when the credentials can run scripts through the script console.
+	scriptConsole bool
+}
+
+// activePlugins returns the short names of the active plugins.
+func activePlugins(plugins []client.Plugin) map[string]bool {
+	active := make(map[string]bool)
+	for _, plugin := range plugins {
+		if plugin.Active {
+			active[plugin.ShortName] = true
+		}
+	}
+
+	return active
+}
+
+// pluginActive reports whether a plugin is active. When the plugins could not be listed, every plugin is assumed
+// to be.
+func pluginActive(plugins map[string]bool, shortName string) bool {
+	return plugins == nil || plugins[shortName]
+}
+
+// detectSecurity detects the authorization strategy, the security realm and the active plugins through the REST
+// API. The matrix strategies have no REST API, they are detected through the script console when it is available,
+// which also tells the external realms apart. Without it, the realm is only known when it is the Jenkins own user
+// database. A configured authorization strategy takes precedence over the detected one, is required when none
+// could be detected, and makes detection failures non-fatal so that Validate can report them.
+func detectSecurity(ctx context.Context, c *client.JenkinsClient, authorizationStrategy string) (*securityConfiguration, error) {
+	l := ctxzap.Extract(ctx)
+	security := &securityConfiguration{}
+	identity, err := c.WhoAmI(ctx)
+	if err != nil {
+		if authorizationStrategy == "" {
+			return nil, fmt.Errorf("jenkins-connector: unable to reach Jenkins, check the base url and the credentials: %w", err)
+		}
+
+		l.Warn("jenkins-connector: unable to detect the security configuration, using the configured authorization strategy",
+			zap.String("authorization_strategy", authorizationStrategy),
+			zap.Error(err),
+		)
+		security.authorizationStrategy = authorizationStrategy
+		return security, nil
+	}
+
+	plugins, err := c.GetPlugins(ctx)
+	if err != nil {
+		l.Warn("jenkins-connector: unable to list plugins, every plugin is assumed to be active", zap.Error(err))
+	} else {
+		security.plugins = activePlugins(plugins)
+	}
+
+	local, err := c.IsLocalSecurityRealm(ctx, identity.Name)
+	if err != nil {
+		l.Warn("jenkins-connector: unable to detect the security realm", zap.Error(err))
+	} else if local {
+		security.securityRealm = localRealm
+	}
+
+	detected := detectAuthorizationStrategy(ctx, c, security.plugins)
+	strategyClass := ""
+	scripted, err := c.GetSecurityConfiguration(ctx)
+	if err != nil {
+		l.Debug("jenkins-connector: the script console is not available, using the security configuration read through the REST API",
+			zap.Error(err),
+		)
+	} else {
+		security.scriptConsole = true
+		strategyClass = scripted.AuthorizationStrategy
+		detected = authorizationStrategyClasses[scripted.AuthorizationStrategy]
+		security.securityRealm = externalRealm
+		if realm, ok := securityRealmClasses[scripted.SecurityRealm]; ok {
+			security.securityRealm = realm
+		}
+	}
+
+	switch {
+	case authorizationStrategy == "" && detected == "" && strategyClass != "":
+		return nil, fmt.Errorf("jenkins-connector: unsupported authorization strategy %s, "+
+			"the connector supports the Role-based, Matrix and Folder-based Authorization Strategies", strategyClass)
+	case authorizationStrategy == "" && detected == "":
+		return nil, fmt.Errorf("jenkins-connector: unable to detect the authorization strategy, set it with --authorization-strategy")
+	case authorizationStrategy == "":
+		authorizationStrategy = detected
+	case detected != "" && detected != authorizationStrategy:
+		l.Warn("jenkins-connector: the configured authorization strategy does not match the one used by Jenkins",
+			zap.String("authorization_strategy", authorizationStrategy),
+			zap.String("detected_authorization_strategy", detected),
+		)
+	}
+
+	security.authorizationStrategy = authorizationStrategy
+	l.Debug("jenkins-connector: security configuration",
+		zap.String("authorization_strategy", security.authorizationStrategy),
+		zap.String("security_realm", security.securityRealm),
+		zap.Bool("script_console", security.scriptConsole),
+	)
+
+	return security, nil
+}
+
+// detectAuthorizationStrategy detects the Role-based and Folder-based Authorization Strategies through their REST
+// endpoints, which only answer while the strategy is in use. It returns an empty strategy otherwise.
+func detectAuthorizationStrategy(ctx context.Context, c *client.JenkinsClient, plugins map[string]bool) string {
+	if pluginActive(plugins, strategyPlugins[roleStrategy]) {
+		if _, err := c.GetRoles(ctx, client.GlobalRoles); err == nil {
+			return roleStrategy
+		}
+	}
+
+	if pluginActive(plugins, strategyPlugins[folderAuthStrategy]) {
+		if _, err := c.GetFolderAuthRoles(ctx, client.FolderAuthGlobalRoles); err == nil {
+			return folderAuthStrategy
+		}
+	}
+
+	return ""
+}
+
+// validateAccess checks that the credentials are authenticated, hold Overall/Administer, and that the plugins
+// providing the authorization strategy and, unless users are read through the script console, the People API are
+// installed and active.
+func validateAccess(ctx context.Context, c *client.JenkinsClient, authorizationStrategy, usersSource string) error {
+	identity, err := c.WhoAmI(ctx)
+	if err != nil {
+		return fmt.Errorf("jenkins-connector: unable to reach Jenkins, check the base url and the credentials: %w", err)
+	}
+
+	if identity.Anonymous || !identity.Authenticated {
+		return fmt.Errorf("jenkins-connector: the credentials are not authenticated, check the username, password or token")
+	}
+
+	plugins, err := c.GetPlugins(ctx)
+	if err != nil {
+		var jenkinsErr *client.JenkinsError
+		if errors.As(err, &jenkinsErr) && jenkinsErr.ErrorCode == http.StatusForbidden {
+			return fmt.Errorf("jenkins-connector: user %s does not have the Overall/Administer permission required to sync and provision", identity.Name)
+		}
+
+		return fmt.Errorf("jenkins-connector: unable to list plugins: %w", err)
+	}
+
+	if usersSource != accountsSource && !versionBefore(identity.Version, 2, 452) {
+		if err := checkPlugin(plugins, peopleViewPlugin); err != nil {
+			return fmt.Errorf("%w, it is required to list users", err)
+		}
+	}
+
+	if err := checkPlugin(plugins, strategyPlugins[authorizationStrategy]); err != nil {
+		return fmt.Errorf("%w, it is required to use the %s authorization strategy", err, authorizationStrategy)
+	}
+
+	return nil
+}
+
+// checkPlugin returns an error when the plugin is missing or inactive.
+func checkPlugin(plugins []client.Plugin, shortName string) error {
+	for _, plugin := range plugins {
+		if plugin.ShortName != shortName {
+			continue
+		}
+
+		if !plugin.Active {
+			return fmt.Errorf("jenkins-connector: the %s plugin is installed but not active", shortName)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("jenkins-connector: the %s plugin is not installed", shortName)
+}
+
+// versionBefore reports whether a Jenkins version such as 2.440.3 is older than major.minor.
+// Unknown versions are never considered older.
+func versionBefore(version string, major, minor int) bool {
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 {
+		return false
+	}
+
+	versionMajor, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return false
+	}
+
+	versionMinor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return false
+	}
+
+	return versionMajor < major || (versionMajor == major && versionMinor < minor)
+}
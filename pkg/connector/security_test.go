@@ -0,0 +1,78 @@
+package connector
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionBefore(t *testing.T) {
+	assert.True(t, versionBefore("2.440.3", 2, 452))
+	assert.True(t, versionBefore("1.651", 2, 452))
+	assert.False(t, versionBefore("2.452", 2, 452))
+	assert.False(t, versionBefore("2.479.1", 2, 452))
+	assert.False(t, versionBefore("", 2, 452))
+}
+
+func TestActivePlugins(t *testing.T) {
+	plugins := activePlugins([]client.Plugin{
+		{ShortName: "credentials", Active: true},
+		{ShortName: "cloudbees-folder", Active: false},
+	})
+	assert.Equal(t, map[string]bool{"credentials": true}, plugins)
+}
+
+func TestResourceSyncersFollowPlugins(t *testing.T) {
+	resourceTypes := func(d *Connector) []string {
+		var ids []string
+		for _, syncer := range d.ResourceSyncers(ctx) {
+			ids = append(ids, syncer.ResourceType(ctx).Id)
+		}
+		return ids
+	}
+
+	d := &Connector{authorizationStrategy: roleStrategy, plugins: map[string]bool{"role-strategy": true}}
+	ids := resourceTypes(d)
+	assert.Contains(t, ids, resourceTypeRole.Id)
+	assert.NotContains(t, ids, resourceTypeFolder.Id)
+	assert.NotContains(t, ids, resourceTypeCredentialStore.Id)
+	assert.NotContains(t, ids, resourceTypeToken.Id)
+
+	d = &Connector{authorizationStrategy: matrixStrategy, plugins: map[string]bool{"matrix-auth": true, "cloudbees-folder": true, "credentials": true}, scriptConsole: true}
+	ids = resourceTypes(d)
+	assert.NotContains(t, ids, resourceTypeRole.Id)
+	assert.Contains(t, ids, resourceTypeMatrix.Id)
+	assert.Contains(t, ids, resourceTypeFolder.Id)
+	assert.Contains(t, ids, resourceTypeCredential.Id)
+	assert.Contains(t, ids, resourceTypeToken.Id)
+}
+
+func TestDetectSecurityWithoutAdministerAccess(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/whoAmI/api/json" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Jenkins", "2.479.1")
+		_, _ = w.Write([]byte(`{"name":"ldap-user","authenticated":true}`))
+	}))
+	defer server.Close()
+
+	c, err := client.New(ctx, server.URL, client.NewClient().WithUser("ldap-user").WithPassword("secret"))
+	assert.Nil(t, err)
+
+	security, err := detectSecurity(ctx, c, matrixStrategy)
+	assert.Nil(t, err)
+	assert.Equal(t, matrixStrategy, security.authorizationStrategy)
+	assert.Equal(t, "", security.securityRealm)
+	assert.Nil(t, security.plugins)
+	assert.False(t, security.scriptConsole)
+
+	_, err = detectSecurity(ctx, c, "")
+	assert.NotNil(t, err)
+}
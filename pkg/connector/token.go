@@ -0,0 +1,108 @@
+package connector
+
+import (
+	"context"
+	"time"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/pagination"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+type tokenBuilder struct {
+	resourceType *v2.ResourceType
+	client       *client.JenkinsClient
+}
+
+// tokenDate formats a token date given in milliseconds since the epoch.
+func tokenDate(millis int64) string {
+	return time.UnixMilli(millis).UTC().Format(time.RFC3339)
+}
+
+// tokenProfile returns when a token was created and how it has been used, so that stale and unused tokens
+// stand out. Dates unknown to Jenkins are left out.
+func tokenProfile(token client.TokenInfo) map[string]interface{} {
+	profile := map[string]interface{}{
+		"token_name": token.Name,
+		"legacy":     token.Legacy,
+		"use_count":  token.UseCounter,
+	}
+	if token.CreationDate != 0 {
+		profile["created_at"] = tokenDate(token.CreationDate)
+	}
+
+	if token.LastUseDate != 0 {
+		profile["last_used_at"] = tokenDate(token.LastUseDate)
+	}
+
+	return profile
+}
+
+// Create a new connector resource for an API token of a user. Tokens have no trait, their profile is attached
+// as an annotation.
+func tokenResource(ctx context.Context, token client.TokenInfo, parentResourceID *v2.ResourceId) (*v2.Resource, error) {
+	profile, err := structpb.NewStruct(tokenProfile(token))
+	if err != nil {
+		return nil, err
+	}
+
+	ret, err := rs.NewResource(
+		token.Name,
+		resourceTypeToken,
+		token.UUID,
+		rs.WithParentResourceID(parentResourceID),
+		rs.WithAnnotation(profile),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+func (t *tokenBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
+	return t.resourceType
+}
+
+// List returns the API tokens of a user. Tokens are only listed as children of users.
+func (t *tokenBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
+	var rv []*v2.Resource
+	if parentResourceID == nil || parentResourceID.ResourceType != resourceTypeUser.Id || parentResourceID.Resource == anonymousUser {
+		return nil, "", nil, nil
+	}
+
+	tokens, err := t.client.GetUserTokens(ctx, parentResourceID.Resource)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	for _, token := range tokens {
+		nr, err := tokenResource(ctx, token, parentResourceID)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		rv = append(rv, nr)
+	}
+
+	return rv, "", nil, nil
+}
+
+// Entitlements always returns an empty slice for tokens.
+func (t *tokenBuilder) Entitlements(_ context.Context, resource *v2.Resource, _ *pagination.Token) ([]*v2.Entitlement, string, annotations.Annotations, error) {
+	return nil, "", nil, nil
+}
+
+// Grants always returns an empty slice for tokens since they don't have any entitlements.
+func (t *tokenBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken *pagination.Token) ([]*v2.Grant, string, annotations.Annotations, error) {
+	return nil, "", nil, nil
+}
+
+func newTokenBuilder(client *client.JenkinsClient) *tokenBuilder {
+	return &tokenBuilder{
+		resourceType: resourceTypeToken,
+		client:       client,
+	}
+}
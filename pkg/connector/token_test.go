@@ -0,0 +1,38 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestTokenProfile(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{
+		"token_name": "ci",
+		"legacy":     false,
+		"use_count":  0,
+		"created_at": "2024-03-01T00:00:00Z",
+	}, tokenProfile(client.TokenInfo{Name: "ci", CreationDate: 1709251200000}))
+	assert.Equal(t, map[string]interface{}{
+		"token_name":   "legacy",
+		"legacy":       true,
+		"use_count":    3,
+		"last_used_at": "2024-03-02T00:00:00Z",
+	}, tokenProfile(client.TokenInfo{Name: "legacy", Legacy: true, UseCounter: 3, LastUseDate: 1709337600000}))
+}
+
+func TestTokenResource(t *testing.T) {
+	resource, err := tokenResource(ctx, client.TokenInfo{UUID: "1234", Name: "ci", UseCounter: 3}, nil)
+	assert.Nil(t, err)
+	assert.Empty(t, resource.Description)
+
+	profile := &structpb.Struct{}
+	annos := annotations.Annotations(resource.Annotations)
+	ok, err := annos.Pick(profile)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, float64(3), profile.Fields["use_count"].GetNumberValue())
+}
@@ -2,18 +2,51 @@ package connector
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/conductorone/baton-jenkins/pkg/client"
 	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
 	"github.com/conductorone/baton-sdk/pkg/annotations"
+	"github.com/conductorone/baton-sdk/pkg/connectorbuilder"
+	"github.com/conductorone/baton-sdk/pkg/crypto"
 	"github.com/conductorone/baton-sdk/pkg/pagination"
 	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/grpc-ecosystem/go-grpc-middleware/logging/zap/ctxzap"
+	"go.uber.org/zap"
 )
 
+// Kinds of users, exposed as the account_kind profile field.
+const (
+	// localAccount users belong to the Jenkins own user database.
+	localAccount = "local"
+	// externalAccount users have authenticated through an external security realm, like LDAP.
+	externalAccount = "external"
+	// scmPerson users are only known as SCM commit authors and have never logged in.
+	scmPerson = "scm"
+)
+
+// userKind classifies a user as a local account, an external account or an SCM-only person.
+func userKind(user client.User) string {
+	switch {
+	case user.IsLocalAccount():
+		return localAccount
+	case user.HasAccount():
+		return externalAccount
+	default:
+		return scmPerson
+	}
+}
+
 type userBuilder struct {
-	resourceType *v2.ResourceType
-	client       *client.JenkinsClient
+	resourceType          *v2.ResourceType
+	client                *client.JenkinsClient
+	authorizationStrategy string
+	securityRealm         string
+	usersSource           string
+	excludeSCMUsers       bool
 }
 
 // Create a new connector resource for a 1Password user.
@@ -34,11 +67,35 @@ func userResource(ctx context.Context, user client.Users, parentResourceID *v2.R
 		"user_id":    user.User.ID,
 	}
 
-	var userStatus v2.UserTrait_Status_Status = v2.UserTrait_Status_STATUS_ENABLED
+	email := user.User.Email()
+	if email != "" {
+		profile["email"] = email
+	}
+
+	if user.User.Description != "" {
+		profile["description"] = user.User.Description
+	}
+
+	// The last change is the last activity Jenkins recorded for the user, like a build or a commit. It is not a
+	// login time.
+	if user.LastChange != 0 {
+		profile["last_change"] = time.UnixMilli(user.LastChange).UTC().Format(time.RFC3339)
+	}
+
+	kind := userKind(user.User)
+	profile["account_kind"] = kind
+
+	// Jenkins has no way to disable an account, but people only known as SCM commit authors cannot log in.
+	userStatus := rs.WithStatus(v2.UserTrait_Status_STATUS_ENABLED)
+	if kind == scmPerson {
+		userStatus = rs.WithDetailedStatus(v2.UserTrait_Status_STATUS_DISABLED, "SCM commit author without a Jenkins account")
+	}
+
 	userTraitOptions := []rs.UserTraitOption{
 		rs.WithUserProfile(profile),
-		rs.WithStatus(userStatus),
-		rs.WithEmail("", true),
+		userStatus,
+		rs.WithEmail(email, true),
+		rs.WithAccountType(v2.UserTrait_ACCOUNT_TYPE_HUMAN),
 	}
 
 	ret, err := rs.NewUserResource(
@@ -47,6 +104,7 @@ func userResource(ctx context.Context, user client.Users, parentResourceID *v2.R
 		user.User.ID,
 		userTraitOptions,
 		rs.WithParentResourceID(parentResourceID),
+		rs.WithAnnotation(&v2.ChildResourceType{ResourceTypeId: resourceTypeToken.Id}),
 	)
 	if err != nil {
 		return nil, err
@@ -59,24 +117,93 @@ func (u *userBuilder) ResourceType(ctx context.Context) *v2.ResourceType {
 	return u.resourceType
 }
 
-// List returns all the users from the database as resource objects.
+// Sources users are listed from.
+const (
+	// peopleSource is the People API.
+	peopleSource = "people"
+	// asynchPeopleSource is the asynchronous People API. Its pages may skip or repeat people, so it is only used
+	// when configured.
+	asynchPeopleSource = "asynch-people"
+	// accountsSource is the user records read through the script console. They only hold the users Jenkins has an
+	// account or a record for, and are read without walking the build history.
+	accountsSource = "accounts"
+)
+
+// usersSource returns where users are listed from, given whether the script console is available and whether the
+// asynchronous People API was asked for. The People API is the fallback when the script console is not available.
+func usersSource(scriptConsole, asynchPeople bool) string {
+	switch {
+	case asynchPeople:
+		return asynchPeopleSource
+	case scriptConsole:
+		return accountsSource
+	default:
+		return peopleSource
+	}
+}
+
+// getUsers returns a page of users from the given source.
+func getUsers(ctx context.Context, c *client.JenkinsClient, source string, start, end int) ([]client.Users, error) {
+	switch source {
+	case accountsSource:
+		return c.GetAccounts(ctx, start, end)
+	case asynchPeopleSource:
+		return c.GetAsynchUsers(ctx, start, end)
+	default:
+		return c.GetUsers(ctx, start, end)
+	}
+}
+
+// isSyncedUser reports whether a user is synced, which is not the case of the built-in principals, listed
+// separately, nor of people only known as SCM commit authors when excludeSCMUsers is set.
+func isSyncedUser(user client.User, excludeSCMUsers bool) bool {
+	if isBuiltInSid(user.ID) {
+		return false
+	}
+
+	return !excludeSCMUsers || userKind(user) != scmPerson
+}
+
+// List returns a page of users as resource objects, skipping people only known as SCM commit authors
+// when configured to. The anonymous user is listed on the first page.
 // Users include a UserTrait because they are the 'shape' of a standard user.
 func (u *userBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId, pToken *pagination.Token) ([]*v2.Resource, string, annotations.Annotations, error) {
-	var rv []*v2.Resource
-	defaultUser := client.Users{
-		User: client.User{
-			Description: "Default user",
-			FullName:    "Anonymous",
-			ID:          "anonymous",
-		},
+	var (
+		rv        []*v2.Resource
+		nextToken string
+	)
+	start, pageSize, err := parsePageToken(pToken)
+	if err != nil {
+		return nil, "", nil, err
 	}
-	users, err := u.client.GetUsers(ctx)
+
+	users, err := getUsers(ctx, u.client, u.usersSource, start, start+pageSize)
 	if err != nil {
 		return nil, "", nil, err
 	}
 
-	users = append(users, defaultUser)
+	if len(users) == pageSize {
+		nextToken = strconv.Itoa(start + pageSize)
+	}
+
+	if start == 0 {
+		anonymousRead, err := anonymousReadAllowed(ctx, u.client, u.authorizationStrategy)
+		if err != nil {
+			return nil, "", nil, err
+		}
+
+		ar, err := anonymousUserResource(anonymousRead, parentResourceID)
+		if err != nil {
+			return nil, "", nil, err
+		}
+		rv = append(rv, ar)
+	}
+
 	for _, user := range users {
+		if !isSyncedUser(user.User, u.excludeSCMUsers) {
+			continue
+		}
+
 		nr, err := userResource(ctx, user, parentResourceID)
 		if err != nil {
 			return nil, "", nil, err
@@ -84,7 +211,7 @@ func (u *userBuilder) List(ctx context.Context, parentResourceID *v2.ResourceId,
 		rv = append(rv, nr)
 	}
 
-	return rv, "", nil, nil
+	return rv, nextToken, nil, nil
 }
 
 // Entitlements always returns an empty slice for users.
@@ -97,9 +224,209 @@ func (u *userBuilder) Grants(ctx context.Context, resource *v2.Resource, pToken
 	return nil, "", nil, nil
 }
 
-func newUserBuilder(client *client.JenkinsClient) *userBuilder {
+// CreateAccount creates a user in the Jenkins own user database with a generated password.
+// The login is the Jenkins user ID. The full name is read from the full_name profile field, or from
+// first_name and last_name, and defaults to the login.
+func (u *userBuilder) CreateAccount(ctx context.Context, accountInfo *v2.AccountInfo, credentialOptions *v2.CredentialOptions) (
+	connectorbuilder.CreateAccountResponse,
+	[]*v2.PlaintextData,
+	annotations.Annotations,
+	error,
+) {
+	login := accountInfo.GetLogin()
+	password, err := crypto.GeneratePassword(credentialOptions)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("jenkins-connector: only random passwords are supported: %w", err)
+	}
+
+	resource, err := u.createAccount(ctx, login, password, accountFullName(accountInfo), accountEmail(accountInfo.GetEmails()))
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	passwordData := &v2.PlaintextData{
+		Name:        "password",
+		Description: fmt.Sprintf("Password of the Jenkins user %s", login),
+		Bytes:       []byte(password),
+	}
+
+	return &v2.CreateAccountResponse_SuccessResult{
+		Resource:              resource,
+		IsCreateAccountResult: true,
+	}, []*v2.PlaintextData{passwordData}, nil, nil
+}
+
+// createAccount creates a user in the Jenkins own user database and returns its resource.
+func (u *userBuilder) createAccount(ctx context.Context, login, password, fullName, email string) (*v2.Resource, error) {
+	l := ctxzap.Extract(ctx)
+	if u.securityRealm != "" && u.securityRealm != localRealm {
+		return nil, fmt.Errorf("jenkins-connector: accounts can only be created with the Jenkins own user database, the security realm is %s", u.securityRealm)
+	}
+
+	if login == "" {
+		return nil, fmt.Errorf("jenkins-connector: a login is required to create an account")
+	}
+
+	err := u.client.CreateAccount(ctx, login, password, fullName, email)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.client.GetUser(ctx, login)
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := userResource(ctx, client.Users{User: *user}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Warn("Account has been created.",
+		zap.String("user", login),
+	)
+
+	return resource, nil
+}
+
+// accountFullName returns the full name of an account from its profile, defaulting to the login.
+func accountFullName(accountInfo *v2.AccountInfo) string {
+	profile := accountInfo.GetProfile().AsMap()
+	if fullName, ok := profile["full_name"].(string); ok && fullName != "" {
+		return fullName
+	}
+
+	firstName, _ := profile["first_name"].(string)
+	lastName, _ := profile["last_name"].(string)
+	if fullName := strings.TrimSpace(firstName + " " + lastName); fullName != "" {
+		return fullName
+	}
+
+	return accountInfo.GetLogin()
+}
+
+// accountEmail returns the primary email of an account, or its first email when none is primary.
+func accountEmail(emails []*v2.AccountInfo_Email) string {
+	var email string
+	for _, e := range emails {
+		if e.GetIsPrimary() {
+			return e.GetAddress()
+		}
+
+		if email == "" {
+			email = e.GetAddress()
+		}
+	}
+
+	return email
+}
+
+// Create creates a user in the Jenkins own user database from a user resource. The login is read from the user
+// trait, or defaults to the resource ID, and the full name is the display name. The user gets a random password
+// that is not returned: account provisioning is the way to create users able to sign in with a password, users
+// created here sign in with an API token rotated for them.
+func (u *userBuilder) Create(ctx context.Context, resource *v2.Resource) (*v2.Resource, annotations.Annotations, error) {
+	var email string
+	login := resource.GetId().GetResource()
+	userTrait, err := rs.GetUserTrait(resource)
+	if err == nil {
+		if userTrait.GetLogin() != "" {
+			login = userTrait.GetLogin()
+		}
+
+		for _, e := range userTrait.GetEmails() {
+			if email == "" || e.GetIsPrimary() {
+				email = e.GetAddress()
+			}
+		}
+	}
+
+	fullName := resource.GetDisplayName()
+	if fullName == "" {
+		fullName = login
+	}
+
+	password, err := crypto.GeneratePassword(&v2.CredentialOptions{
+		Options: &v2.CredentialOptions_RandomPassword_{
+			RandomPassword: &v2.CredentialOptions_RandomPassword{Length: 32},
+		},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	nr, err := u.createAccount(ctx, login, password, fullName, email)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return nr, nil, nil
+}
+
+// Delete removes the user from every role or matrix entry, then deletes the user.
+func (u *userBuilder) Delete(ctx context.Context, resourceId *v2.ResourceId) (annotations.Annotations, error) {
+	l := ctxzap.Extract(ctx)
+	userId := resourceId.Resource
+	if isBuiltInSid(userId) {
+		return nil, fmt.Errorf("jenkins-connector: %s is a built-in user and cannot be deleted", userId)
+	}
+
+	err := removeUserPermissions(ctx, u.client, u.authorizationStrategy, userId)
+	if err != nil {
+		return nil, fmt.Errorf("jenkins-connector: unable to remove the permissions of user %s: %w", userId, err)
+	}
+
+	err = u.client.DeleteUser(ctx, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	l.Warn("User has been deleted.",
+		zap.String("user", userId),
+	)
+
+	return nil, nil
+}
+
+// Rotate generates a new named API token for the user and revokes every other token of the user.
+func (u *userBuilder) Rotate(ctx context.Context, resourceId *v2.ResourceId, credentialOptions *v2.CredentialOptions) (
+	[]*v2.PlaintextData,
+	annotations.Annotations,
+	error,
+) {
+	l := ctxzap.Extract(ctx)
+	userId := resourceId.Resource
+	if userId == anonymousUser {
+		return nil, nil, fmt.Errorf("jenkins-connector: the anonymous user has no API tokens")
+	}
+
+	tokenName := fmt.Sprintf("baton-%s", time.Now().UTC().Format("20060102T150405Z"))
+	token, err := u.client.RotateToken(ctx, userId, tokenName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	l.Warn("API token has been rotated.",
+		zap.String("user", userId),
+		zap.String("token", token.TokenName),
+	)
+
+	tokenData := &v2.PlaintextData{
+		Name:        "api_token",
+		Description: fmt.Sprintf("API token %s of the Jenkins user %s", token.TokenName, userId),
+		Bytes:       []byte(token.TokenValue),
+	}
+
+	return []*v2.PlaintextData{tokenData}, nil, nil
+}
+
+func newUserBuilder(client *client.JenkinsClient, authorizationStrategy, securityRealm, usersSource string, excludeSCMUsers bool) *userBuilder {
 	return &userBuilder{
-		resourceType: resourceTypeUser,
-		client:       client,
+		resourceType:          resourceTypeUser,
+		client:                client,
+		authorizationStrategy: authorizationStrategy,
+		securityRealm:         securityRealm,
+		usersSource:           usersSource,
+		excludeSCMUsers:       excludeSCMUsers,
 	}
 }
@@ -0,0 +1,143 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/conductorone/baton-jenkins/pkg/client"
+	v2 "github.com/conductorone/baton-sdk/pb/c1/connector/v2"
+	"github.com/conductorone/baton-sdk/pkg/annotations"
+	rs "github.com/conductorone/baton-sdk/pkg/types/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserKind(t *testing.T) {
+	local := client.User{ID: "jane", Property: []client.UserProperty{
+		{Class: "hudson.security.HudsonPrivateSecurityRealm$Details"},
+		{Class: "jenkins.security.LastGrantedAuthoritiesProperty"},
+	}}
+	external := client.User{ID: "bob", Property: []client.UserProperty{
+		{Class: "jenkins.security.LastGrantedAuthoritiesProperty"},
+	}}
+	committer := client.User{ID: "alice", Property: []client.UserProperty{
+		{Class: "hudson.tasks.Mailer$UserProperty", Address: "alice@example.com"},
+	}}
+
+	assert.Equal(t, localAccount, userKind(local))
+	assert.Equal(t, externalAccount, userKind(external))
+	assert.Equal(t, scmPerson, userKind(committer))
+
+	resource, err := userResource(context.Background(), client.Users{User: committer}, nil)
+	assert.Nil(t, err)
+	trait, err := rs.GetUserTrait(resource)
+	assert.Nil(t, err)
+	assert.Equal(t, v2.UserTrait_ACCOUNT_TYPE_HUMAN, trait.AccountType)
+	kind, _ := rs.GetProfileStringValue(trait.Profile, "account_kind")
+	assert.Equal(t, scmPerson, kind)
+	assert.Equal(t, "alice@example.com", trait.Emails[0].Address)
+}
+
+func TestIsSyncedUser(t *testing.T) {
+	committer := client.User{ID: "alice"}
+	assert.True(t, isSyncedUser(committer, false))
+	assert.False(t, isSyncedUser(committer, true))
+	assert.False(t, isSyncedUser(client.User{ID: anonymousUser}, false))
+}
+
+func TestIsAuthenticatedMember(t *testing.T) {
+	committer := client.User{ID: "alice"}
+	assert.True(t, isSyncedUser(committer, false))
+	assert.False(t, isAuthenticatedMember(committer))
+
+	external := client.User{ID: "bob", Property: []client.UserProperty{
+		{Class: "jenkins.security.LastGrantedAuthoritiesProperty"},
+	}}
+	assert.True(t, isAuthenticatedMember(external))
+	assert.False(t, isAuthenticatedMember(client.User{ID: anonymousUser}))
+}
+
+func TestUsersSource(t *testing.T) {
+	assert.Equal(t, accountsSource, usersSource(true, false))
+	assert.Equal(t, peopleSource, usersSource(false, false))
+	assert.Equal(t, asynchPeopleSource, usersSource(true, true))
+	assert.Equal(t, asynchPeopleSource, usersSource(false, true))
+}
+
+func TestUserResourceLastChange(t *testing.T) {
+	user := client.Users{
+		LastChange: 1709251200000,
+		User:       client.User{ID: "jane", FullName: "Jane Doe"},
+	}
+	resource, err := userResource(ctx, user, nil)
+	assert.Nil(t, err)
+
+	userTrait := &v2.UserTrait{}
+	annos := annotations.Annotations(resource.Annotations)
+	ok, err := annos.Pick(userTrait)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "2024-03-01T00:00:00Z", userTrait.Profile.Fields["last_change"].GetStringValue())
+	assert.Nil(t, userTrait.LastLogin)
+}
+
+func TestUserResourceStatus(t *testing.T) {
+	status := func(user client.User) v2.UserTrait_Status_Status {
+		resource, err := userResource(ctx, client.Users{User: user}, nil)
+		assert.Nil(t, err)
+		userTrait, err := rs.GetUserTrait(resource)
+		assert.Nil(t, err)
+		return userTrait.GetStatus().GetStatus()
+	}
+
+	assert.Equal(t, v2.UserTrait_Status_STATUS_ENABLED, status(client.User{ID: "jane", Property: []client.UserProperty{
+		{Class: "hudson.security.HudsonPrivateSecurityRealm$Details"},
+	}}))
+	assert.Equal(t, v2.UserTrait_Status_STATUS_ENABLED, status(client.User{ID: "bob", Property: []client.UserProperty{
+		{Class: "jenkins.security.LastGrantedAuthoritiesProperty"},
+	}}))
+	assert.Equal(t, v2.UserTrait_Status_STATUS_DISABLED, status(client.User{ID: "committer"}))
+}
+
+func TestCreateUser(t *testing.T) {
+	var form map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/securityRealm/createAccountByAdmin":
+			assert.Nil(t, r.ParseForm())
+			form = map[string]string{
+				"username": r.PostForm.Get("username"),
+				"fullname": r.PostForm.Get("fullname"),
+				"email":    r.PostForm.Get("email"),
+			}
+			http.Redirect(w, r, "/securityRealm/", http.StatusFound)
+		case "/securityRealm/":
+			_, _ = w.Write([]byte("<html></html>"))
+		case "/user/jane/api/json":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"id":"jane","fullName":"Jane Doe"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	c, err := client.New(ctx, server.URL, client.NewClient().WithUser("admin").WithPassword("admin"))
+	assert.Nil(t, err)
+
+	resource, err := rs.NewUserResource("Jane Doe", resourceTypeUser, "jane", []rs.UserTraitOption{
+		rs.WithEmail("jane@example.com", true),
+	})
+	assert.Nil(t, err)
+
+	u := newUserBuilder(c, roleStrategy, localRealm, accountsSource, false)
+	user, _, err := u.Create(ctx, resource)
+	assert.Nil(t, err)
+	assert.Equal(t, "jane", user.Id.Resource)
+	assert.Equal(t, map[string]string{"username": "jane", "fullname": "Jane Doe", "email": "jane@example.com"}, form)
+
+	u = newUserBuilder(c, roleStrategy, ldapRealm, accountsSource, false)
+	_, _, err = u.Create(ctx, resource)
+	assert.ErrorContains(t, err, "Jenkins own user database")
+}